@@ -1,8 +1,10 @@
 resource "docker_image" "gateway_image" {
   name = "gateway_image"
   build {
-    path = "../services/gateway"
-    tag  = ["collectable/gateway:latest"]
+    # The repository root is the context so that the service can build the libraries it replaces.
+    path       = ".."
+    dockerfile = "services/gateway/Dockerfile"
+    tag        = ["collectable/gateway:latest"]
   }
 }
 
@@ -19,8 +21,10 @@ resource "helm_release" "gateway_service_release" {
 resource "docker_image" "iam_image" {
   name = "iam_image"
   build {
-    path = "../services/iam"
-    tag  = ["collectable/iam:latest"]
+    # The repository root is the context so that the service can build the libraries it replaces.
+    path       = ".."
+    dockerfile = "services/iam/Dockerfile"
+    tag        = ["collectable/iam:latest"]
   }
 }
 
@@ -0,0 +1,234 @@
+package lgr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// defaultAsyncBufferSize is the number of entries buffered by an AsyncAdapter when no size is configured.
+const defaultAsyncBufferSize = 1024
+
+// droppedFieldKey is the key of the field that reports how many entries were dropped before an entry.
+const droppedFieldKey = "dropped"
+
+// OverflowPolicy determines what an AsyncAdapter does with an entry when its buffer is full.
+type OverflowPolicy int
+
+const (
+	// DropOnOverflow drops the entry so that the caller is never blocked. The number of dropped entries
+	// is added to the next entry that is buffered with the key "dropped". This is the default policy.
+	DropOnOverflow OverflowPolicy = iota
+	// BlockOnOverflow blocks the caller until there is space in the buffer so that no entries are lost.
+	BlockOnOverflow
+)
+
+// AsyncConfig configures an AsyncAdapter.
+type AsyncConfig struct {
+	// BufferSize is the number of entries that can be buffered before the Policy applies. The default
+	// is 1024.
+	BufferSize int
+	// Policy determines what happens to entries when the buffer is full.
+	Policy OverflowPolicy
+}
+
+// WithAsync configures the Logger created by New to write logs asynchronously so that logging does not
+// block on the output. Logger.Sync and Logger.Close should be called on shutdown to drain the buffer.
+func WithAsync(config AsyncConfig) Option {
+	return func(l *Logger) {
+		l.async = &config
+	}
+}
+
+// AsyncAdapter is an Adapter that buffers entries in a bounded ring buffer and writes them to another
+// Adapter from a background goroutine so that the caller does not wait for the log to be written.
+type AsyncAdapter struct {
+	adapter Adapter
+	policy  OverflowPolicy
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	buffer   []Entry
+	head     int
+	count    int
+	writing  bool
+	dropped  uint64
+	closed   bool
+	done     chan struct{}
+	// idle is closed when the buffer is empty and no entry is being written. It is replaced with a new
+	// channel when an entry is buffered so that Sync can wait for the buffer to drain.
+	idle chan struct{}
+}
+
+// NewAsyncAdapter creates a new AsyncAdapter that writes entries to the given Adapter and starts the
+// background goroutine that writes them. Close must be called to stop the goroutine.
+func NewAsyncAdapter(adapter Adapter, config AsyncConfig) *AsyncAdapter {
+	size := config.BufferSize
+	if size <= 0 {
+		size = defaultAsyncBufferSize
+	}
+
+	async := &AsyncAdapter{
+		adapter:  adapter,
+		policy:   config.Policy,
+		mu:       sync.Mutex{},
+		notEmpty: nil,
+		notFull:  nil,
+		buffer:   make([]Entry, size),
+		head:     0,
+		count:    0,
+		writing:  false,
+		dropped:  0,
+		closed:   false,
+		done:     make(chan struct{}),
+		idle:     make(chan struct{}),
+	}
+
+	async.notEmpty = sync.NewCond(&async.mu)
+	async.notFull = sync.NewCond(&async.mu)
+
+	close(async.idle)
+
+	go async.run()
+
+	return async
+}
+
+// Adapt buffers the log to be written by the background goroutine.
+func (a *AsyncAdapter) Adapt(level Level, message string, fields ...Field) {
+	a.AdaptEntry(newEntry(level, message, fields))
+}
+
+// AdaptEntry buffers the entry to be written by the background goroutine. When the buffer is full the
+// entry is either dropped or the caller is blocked depending on the OverflowPolicy. Entries are always
+// dropped once the adapter has been closed.
+func (a *AsyncAdapter) AdaptEntry(entry Entry) {
+	// The fields are copied as the caller is free to reuse the slice once the call returns.
+	entry.Fields = append([]Field(nil), entry.Fields...)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for a.policy == BlockOnOverflow && a.count == len(a.buffer) && !a.closed {
+		a.notFull.Wait()
+	}
+
+	if a.closed || a.count == len(a.buffer) {
+		a.dropped++
+
+		return
+	}
+
+	if a.dropped > 0 {
+		entry.Fields = append(entry.Fields, Integer(droppedFieldKey, a.dropped))
+		a.dropped = 0
+	}
+
+	if a.count == 0 && !a.writing {
+		a.idle = make(chan struct{})
+	}
+
+	a.buffer[(a.head+a.count)%len(a.buffer)] = entry
+	a.count++
+
+	a.notEmpty.Signal()
+}
+
+// Sync waits for the buffered entries to be written and then syncs the underlying Adapter if it
+// implements Syncer. It returns early with an error if ctx is done before the buffer is drained.
+func (a *AsyncAdapter) Sync(ctx context.Context) error {
+	a.mu.Lock()
+	idle := a.idle
+	a.mu.Unlock()
+
+	select {
+	case <-idle:
+	case <-ctx.Done():
+		return fmt.Errorf("draining async buffer: %w", ctx.Err())
+	}
+
+	if syncer, ok := a.adapter.(Syncer); ok {
+		if err := syncer.Sync(ctx); err != nil {
+			return fmt.Errorf("syncing async adapter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close stops accepting entries, waits for the buffered entries to be written and then closes the
+// underlying Adapter if it implements io.Closer.
+func (a *AsyncAdapter) Close() error {
+	a.mu.Lock()
+
+	if a.closed {
+		a.mu.Unlock()
+
+		return nil
+	}
+
+	a.closed = true
+	a.notEmpty.Broadcast()
+	a.notFull.Broadcast()
+	a.mu.Unlock()
+
+	<-a.done
+
+	if closer, ok := a.adapter.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("closing async adapter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// run writes buffered entries to the underlying Adapter until the adapter is closed and the buffer
+// has been drained.
+func (a *AsyncAdapter) run() {
+	defer close(a.done)
+
+	for {
+		entry, ok := a.next()
+		if !ok {
+			return
+		}
+
+		adaptEntry(a.adapter, entry)
+
+		a.mu.Lock()
+		a.writing = false
+
+		if a.count == 0 {
+			close(a.idle)
+		}
+		a.mu.Unlock()
+	}
+}
+
+// next waits for an entry to be buffered and removes it from the buffer. It returns false once the
+// adapter is closed and the buffer is empty.
+func (a *AsyncAdapter) next() (Entry, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for a.count == 0 && !a.closed {
+		a.notEmpty.Wait()
+	}
+
+	if a.count == 0 {
+		return Entry{}, false //nolint: exhaustruct // The entry is discarded.
+	}
+
+	entry := a.buffer[a.head]
+	a.buffer[a.head] = Entry{} //nolint: exhaustruct // Release the fields for garbage collection.
+	a.head = (a.head + 1) % len(a.buffer)
+	a.count--
+	a.writing = true
+
+	a.notFull.Signal()
+
+	return entry, true
+}
@@ -0,0 +1,187 @@
+package lgr_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+// gatedAdapter is an Adapter that blocks each write until the gate is opened so that the buffer of
+// an AsyncAdapter can be filled.
+type gatedAdapter struct {
+	gate    chan struct{}
+	started chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	entries []lgr.Entry
+}
+
+func newGatedAdapter() *gatedAdapter {
+	return &gatedAdapter{gate: make(chan struct{}), started: make(chan struct{}), once: sync.Once{}, mu: sync.Mutex{}}
+}
+
+func (g *gatedAdapter) Adapt(level lgr.Level, message string, fields ...lgr.Field) {
+	g.AdaptEntry(lgr.Entry{Level: level, Message: message, Fields: fields})
+}
+
+func (g *gatedAdapter) AdaptEntry(entry lgr.Entry) {
+	g.once.Do(func() { close(g.started) })
+	<-g.gate
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.entries = append(g.entries, entry)
+}
+
+func (g *gatedAdapter) messages() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	messages := make([]string, len(g.entries))
+	for i, entry := range g.entries {
+		messages[i] = entry.Message
+	}
+
+	return messages
+}
+
+func TestAsyncAdapter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes entries in order and drains on sync", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		async := lgr.NewAsyncAdapter(adapter, lgr.AsyncConfig{BufferSize: 0, Policy: lgr.BlockOnOverflow})
+		logger := lgr.FromAdapter(async).With(lgr.Str("boundKey", "some bound string"))
+
+		for i := 0; i < 100; i++ {
+			logger.Info("my info message", lgr.Integer("i", i))
+		}
+
+		require.NoError(t, logger.Sync(context.Background()))
+		require.Len(t, entries.All(), 100)
+
+		for i, entry := range entries.All() {
+			lgrtest.AssertFullEntry(t, entry, lgr.InfoLevel, "my info message",
+				lgr.Str("boundKey", "some bound string"), lgr.Integer("i", i))
+		}
+
+		assert.NoError(t, async.Close())
+	})
+
+	t.Run("drops entries when full and reports the count on the next entry", func(t *testing.T) {
+		t.Parallel()
+
+		gated := newGatedAdapter()
+		async := lgr.NewAsyncAdapter(gated, lgr.AsyncConfig{BufferSize: 1, Policy: lgr.DropOnOverflow})
+
+		async.Adapt(lgr.InfoLevel, "first")
+		<-gated.started // The first entry is being written so the buffer is empty.
+		async.Adapt(lgr.InfoLevel, "second")
+		async.Adapt(lgr.InfoLevel, "dropped")
+		async.Adapt(lgr.InfoLevel, "dropped")
+
+		close(gated.gate)
+		require.NoError(t, async.Sync(context.Background()))
+		async.Adapt(lgr.InfoLevel, "third")
+		require.NoError(t, async.Close())
+
+		assert.Equal(t, []string{"first", "second", "third"}, gated.messages())
+		assert.Equal(t, []lgr.Field{lgr.Integer("dropped", uint64(2))}, gated.entries[2].Fields)
+	})
+
+	t.Run("blocks when full", func(t *testing.T) {
+		t.Parallel()
+
+		gated := newGatedAdapter()
+		async := lgr.NewAsyncAdapter(gated, lgr.AsyncConfig{BufferSize: 1, Policy: lgr.BlockOnOverflow})
+
+		async.Adapt(lgr.InfoLevel, "first")
+		<-gated.started
+		async.Adapt(lgr.InfoLevel, "second")
+
+		written := make(chan struct{})
+
+		go func() {
+			async.Adapt(lgr.InfoLevel, "third")
+			close(written)
+		}()
+
+		select {
+		case <-written:
+			t.Fatal("expected the write to block while the buffer is full")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		close(gated.gate)
+		<-written
+		require.NoError(t, async.Close())
+
+		assert.Equal(t, []string{"first", "second", "third"}, gated.messages())
+	})
+
+	t.Run("returns an error when sync times out", func(t *testing.T) {
+		t.Parallel()
+
+		gated := newGatedAdapter()
+		async := lgr.NewAsyncAdapter(gated, lgr.AsyncConfig{BufferSize: 1, Policy: lgr.DropOnOverflow})
+
+		async.Adapt(lgr.InfoLevel, "first")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, async.Sync(ctx), context.DeadlineExceeded)
+
+		close(gated.gate)
+		assert.NoError(t, async.Close())
+	})
+
+	t.Run("drains the buffer and closes the adapter on close", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := &syncRecorder{calls: nil}
+		logger := lgr.FromAdapter(lgr.NewAsyncAdapter(adapter, lgr.AsyncConfig{BufferSize: 10, Policy: lgr.DropOnOverflow}))
+
+		logger.Info("first")
+		logger.Info("second")
+		require.NoError(t, logger.Close())
+		logger.Info("dropped")
+
+		assert.Equal(t, []string{"adapt: first", "adapt: second", "sync"}, adapter.calls)
+	})
+}
+
+func TestWithAsync(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := lgr.New(
+		lgr.WithOutputPath(path),
+		lgr.WithTimestampFactory(func() time.Time { return time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC) }),
+		lgr.WithAsync(lgr.AsyncConfig{BufferSize: 0, Policy: lgr.BlockOnOverflow}),
+	)
+	require.NoError(t, err)
+
+	logger.Info("my info message")
+	require.NoError(t, logger.Close())
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"level":"info","context":{},"timestamp":"2022-03-05T00:00:00Z","message":"my info message"}`+"\n",
+		string(contents),
+	)
+}
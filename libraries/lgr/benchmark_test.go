@@ -0,0 +1,134 @@
+package lgr_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+// fieldSink prevents the compiler from optimising away the construction of fields in benchmarks.
+var fieldSink lgr.Field //nolint: gochecknoglobals // Required to benchmark field construction.
+
+// discardAdapter is an Adapter that discards every log so that benchmarks measure the cost of the Logger.
+type discardAdapter struct{}
+
+func (discardAdapter) Adapt(lgr.Level, string, ...lgr.Field) {}
+
+func TestZeroAllocations(t *testing.T) { //nolint: paralleltest // AllocsPerRun can not be called during a parallel test.
+	logger := lgr.FromAdapter(discardAdapter{}, lgr.WithMinLevel(lgr.InfoLevel)).With(lgr.Str("requestId", "abc-123"))
+	now := time.Now()
+
+	testCases := map[string]func(){
+		"disabled level": func() {
+			logger.Debug("my debug message")
+		},
+		"disabled level with fields": func() {
+			logger.Debug("my debug message", lgr.Str("strKey", "some string"), lgr.Integer("intKey", 42), lgr.Bool("boolKey", true))
+		},
+		"disabled check": func() {
+			if ce := logger.Check(lgr.DebugLevel, "my debug message"); ce != nil {
+				ce.Write(lgr.Str("strKey", "some string"))
+			}
+		},
+		"str field":      func() { fieldSink = lgr.Str("strKey", "some string") },
+		"integer field":  func() { fieldSink = lgr.Integer("intKey", 42) },
+		"uint64 field":   func() { fieldSink = lgr.Integer("uint64Key", uint64(42)) },
+		"float field":    func() { fieldSink = lgr.Float("floatKey", 4.2) },
+		"bool field":     func() { fieldSink = lgr.Bool("boolKey", true) },
+		"duration field": func() { fieldSink = lgr.Duration("durationKey", time.Second) },
+		"time field":     func() { fieldSink = lgr.Time("timeKey", now) },
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) { //nolint: paralleltest // AllocsPerRun can not be called during a parallel test.
+			assert.Zero(t, testing.AllocsPerRun(100, tc))
+		})
+	}
+}
+
+func BenchmarkDisabledLevel(b *testing.B) {
+	logger := lgr.FromAdapter(discardAdapter{}, lgr.WithMinLevel(lgr.InfoLevel))
+
+	b.Run("without fields", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			logger.Debug("my debug message")
+		}
+	})
+
+	b.Run("with fields", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			logger.Debug("my debug message",
+				lgr.Str("strKey", "some string"),
+				lgr.Integer("intKey", i),
+				lgr.Float("floatKey", 4.2),
+				lgr.Bool("boolKey", true),
+				lgr.Duration("durationKey", time.Second),
+			)
+		}
+	})
+}
+
+func BenchmarkCheck(b *testing.B) {
+	logger := lgr.FromAdapter(discardAdapter{}, lgr.WithMinLevel(lgr.InfoLevel))
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if ce := logger.Check(lgr.DebugLevel, "my debug message"); ce != nil {
+			ce.Write(lgr.Integer("intKey", i))
+		}
+	}
+}
+
+func BenchmarkFields(b *testing.B) {
+	now := time.Now()
+	err := errors.New("some error")
+
+	benchmarks := map[string]func(i int) lgr.Field{
+		"Str":      func(int) lgr.Field { return lgr.Str("strKey", "some string") },
+		"Integer":  func(i int) lgr.Field { return lgr.Integer("intKey", i) },
+		"Float":    func(i int) lgr.Field { return lgr.Float("floatKey", float64(i)) },
+		"Bool":     func(i int) lgr.Field { return lgr.Bool("boolKey", i%2 == 0) },
+		"Duration": func(i int) lgr.Field { return lgr.Duration("durationKey", time.Duration(i)) },
+		"Time":     func(int) lgr.Field { return lgr.Time("timeKey", now) },
+		"Err":      func(int) lgr.Field { return lgr.Err(err) },
+	}
+
+	for name, benchmark := range benchmarks {
+		construct := benchmark
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				fieldSink = construct(i)
+			}
+		})
+	}
+}
+
+func BenchmarkEnabledLevel(b *testing.B) {
+	logger := lgr.FromAdapter(discardAdapter{}).With(lgr.Str("requestId", "abc-123"))
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		logger.Info("my info message",
+			lgr.Str("strKey", "some string"),
+			lgr.Integer("intKey", i),
+			lgr.Float("floatKey", 4.2),
+			lgr.Bool("boolKey", true),
+			lgr.Duration("durationKey", time.Second),
+		)
+	}
+}
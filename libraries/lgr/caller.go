@@ -0,0 +1,118 @@
+package lgr
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+// logCallerSkip is the number of frames between runtime.Callers and the code that called one of
+// the public logging methods such as Logger.Info:
+//
+//	runtime.Callers -> callers -> Logger.log -> Logger.Info -> caller
+const logCallerSkip = 4
+
+// Caller describes the location in the source code that wrote an Entry.
+type Caller struct {
+	// Defined reports whether the caller was captured. It is false when the Logger was not created
+	// with WithCaller or the caller could not be determined.
+	Defined bool
+	// PC is the program counter of the call site.
+	PC uintptr
+	// Function is the fully qualified name of the function that wrote the entry.
+	Function string
+	// File is the full path of the file that wrote the entry.
+	File string
+	// Line is the line number within File that wrote the entry.
+	Line int
+}
+
+// String returns the caller formatted as "file:line" or "undefined" if the caller is not Defined.
+func (c Caller) String() string {
+	if !c.Defined {
+		return "undefined"
+	}
+
+	return c.File + ":" + strconv.Itoa(c.Line)
+}
+
+// WithCaller adds the location of the code that wrote each log to the Entry so that it can be
+// written by the Adapter.
+func WithCaller() Option {
+	return func(l *Logger) {
+		l.addCaller = true
+	}
+}
+
+// WithStackTraceAt adds the stack trace of the goroutine that wrote the log to each Entry written
+// at or above the given level. This is typically set to ErrorLevel so that the path to an error can
+// be found without having to reproduce it.
+func WithStackTraceAt(level Level) Option {
+	return func(l *Logger) {
+		l.addStack = true
+		l.stackLevel = level
+	}
+}
+
+// callers returns up to depth program counters from the stack of the calling goroutine, skipping
+// the given number of frames as runtime.Callers does.
+func callers(skip, depth int) []uintptr {
+	pcs := make([]uintptr, depth)
+
+	return pcs[:runtime.Callers(skip, pcs)]
+}
+
+// callerFromPC resolves the given program counter to a Caller.
+func callerFromPC(pc uintptr) Caller {
+	if pc == 0 {
+		return Caller{Defined: false, PC: 0, Function: "", File: "", Line: 0}
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	return Caller{
+		Defined:  frame.PC != 0,
+		PC:       pc,
+		Function: frame.Function,
+		File:     frame.File,
+		Line:     frame.Line,
+	}
+}
+
+// formatFramesFrom formats the frames of the given program counters like formatFrames, starting at the
+// frame of the call site pc so that the frames of a bridge, such as log/slog, are left out. Every frame
+// is formatted when pc is zero or is not on the stack.
+func formatFramesFrom(pcs []uintptr, pc uintptr) []string {
+	stack := formatFrames(pcs)
+	if pc == 0 {
+		return stack
+	}
+
+	caller := callerFromPC(pc)
+	site := fmt.Sprintf("%s %s:%d", caller.Function, caller.File, caller.Line)
+
+	for i, frame := range stack {
+		if frame == site {
+			return stack[i:]
+		}
+	}
+
+	return stack
+}
+
+// formatFrames formats each frame of the given program counters as "function file:line".
+func formatFrames(pcs []uintptr) []string {
+	frames := runtime.CallersFrames(pcs)
+	formatted := make([]string, 0, len(pcs))
+
+	for {
+		frame, more := frames.Next()
+		if frame.PC != 0 {
+			formatted = append(formatted, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+
+		if !more {
+			return formatted
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package lgr_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+// entryRecorder is an lgr.EntryAdapter that records each lgr.Entry so that metadata can be asserted.
+type entryRecorder struct {
+	entries []lgr.Entry
+}
+
+func (r *entryRecorder) Adapt(level lgr.Level, message string, fields ...lgr.Field) {
+	r.AdaptEntry(lgr.Entry{Level: level, Message: message, Fields: fields})
+}
+
+func (r *entryRecorder) AdaptEntry(entry lgr.Entry) {
+	r.entries = append(r.entries, entry)
+}
+
+// nextLine returns the line number after the line that it was called from.
+func nextLine() int {
+	_, _, line, _ := runtime.Caller(1)
+
+	return line + 1
+}
+
+func TestWithCaller(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports the call site of each logging method", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		logger := lgr.FromAdapter(recorder, lgr.WithCaller())
+		ctx := context.Background()
+
+		var wantLines []int
+
+		wantLines = append(wantLines, nextLine())
+		logger.Debug("my debug message")
+		wantLines = append(wantLines, nextLine())
+		logger.Info("my info message")
+		wantLines = append(wantLines, nextLine())
+		logger.Warn("my warn message")
+		wantLines = append(wantLines, nextLine())
+		logger.Error("my error message")
+		wantLines = append(wantLines, nextLine())
+		logger.DebugCtx(ctx, "my debug ctx message")
+		wantLines = append(wantLines, nextLine())
+		logger.InfoCtx(ctx, "my info ctx message")
+		wantLines = append(wantLines, nextLine())
+		logger.WarnCtx(ctx, "my warn ctx message")
+		wantLines = append(wantLines, nextLine())
+		logger.ErrorCtx(ctx, "my error ctx message")
+		wantLines = append(wantLines, nextLine())
+		logger.With(lgr.Str("strKey", "some string")).Named("child").Info("my child message")
+		wantLines = append(wantLines, nextLine())
+		logger.Check(lgr.InfoLevel, "my checked message").Write()
+		wantLines = append(wantLines, nextLine())
+		logger.Check(lgr.InfoLevel, "my checked ctx message").WriteCtx(ctx)
+
+		require.Len(t, recorder.entries, len(wantLines))
+
+		for i, entry := range recorder.entries {
+			assert.True(t, entry.Caller.Defined, "caller is not defined for %s", entry.Message)
+			assert.Equal(t, "caller_test.go", filepath.Base(entry.Caller.File), "file does not match for %s", entry.Message)
+			assert.Equal(t, wantLines[i], entry.Caller.Line, "line does not match for %s", entry.Message)
+			assert.True(t, strings.HasSuffix(entry.Caller.Function, "TestWithCaller.func1"), entry.Caller.Function)
+			assert.Equal(t, fmt.Sprintf("%s:%d", entry.Caller.File, wantLines[i]), entry.Caller.String())
+		}
+	})
+
+	t.Run("does not report the call site by default", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		lgr.FromAdapter(recorder).Info("my info message")
+
+		require.Len(t, recorder.entries, 1)
+		assert.False(t, recorder.entries[0].Caller.Defined)
+		assert.Equal(t, "undefined", recorder.entries[0].Caller.String())
+	})
+
+	t.Run("reports the call site of slog records", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		slogger := slog.New(lgr.NewSlogHandler(recorder, lgr.WithCaller(), lgr.WithStackTraceAt(lgr.InfoLevel)))
+
+		wantLine := nextLine()
+		slogger.Info("my info message")
+
+		require.Len(t, recorder.entries, 1)
+		assert.Equal(t, "caller_test.go", filepath.Base(recorder.entries[0].Caller.File))
+		assert.Equal(t, wantLine, recorder.entries[0].Caller.Line)
+
+		require.NotEmpty(t, recorder.entries[0].Stack)
+		assert.True(
+			t,
+			strings.HasPrefix(recorder.entries[0].Stack[0], "github.com/nickbryan/collectable/libraries/lgr_test.TestWithCaller.func"),
+			recorder.entries[0].Stack[0],
+		)
+		assert.True(t, strings.HasSuffix(recorder.entries[0].Stack[0], fmt.Sprintf("caller_test.go:%d", wantLine)))
+	})
+
+	t.Run("reports the call site of zap entries", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		zlogger := zap.New(
+			lgr.NewZapCore(lgr.FromAdapter(recorder, lgr.WithCaller(), lgr.WithStackTraceAt(lgr.InfoLevel))),
+			zap.AddCaller(),
+		)
+
+		wantLine := nextLine()
+		zlogger.Info("my info message")
+
+		require.Len(t, recorder.entries, 1)
+		assert.Equal(t, "caller_test.go", filepath.Base(recorder.entries[0].Caller.File))
+		assert.Equal(t, wantLine, recorder.entries[0].Caller.Line)
+
+		require.NotEmpty(t, recorder.entries[0].Stack)
+		assert.True(t, strings.HasSuffix(recorder.entries[0].Stack[0], fmt.Sprintf("caller_test.go:%d", wantLine)))
+	})
+}
+
+func TestWithStackTraceAt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("adds the stack trace at or above the level", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		logger := lgr.FromAdapter(recorder, lgr.WithStackTraceAt(lgr.WarnLevel))
+
+		logger.Info("my info message")
+		logger.Warn("my warn message")
+		logger.ErrorCtx(context.Background(), "my error message")
+
+		require.Len(t, recorder.entries, 3)
+		assert.Empty(t, recorder.entries[0].Stack)
+
+		for _, entry := range recorder.entries[1:] {
+			require.NotEmpty(t, entry.Stack, "stack is empty for %s", entry.Message)
+			assert.True(
+				t,
+				strings.HasPrefix(entry.Stack[0], "github.com/nickbryan/collectable/libraries/lgr_test.TestWithStackTraceAt.func1 "),
+				entry.Stack[0],
+			)
+			assert.Contains(t, entry.Stack[0], "caller_test.go:")
+		}
+	})
+
+	t.Run("does not add the stack trace by default", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		lgr.FromAdapter(recorder).Error("my error message")
+
+		require.Len(t, recorder.entries, 1)
+		assert.Empty(t, recorder.entries[0].Stack)
+	})
+}
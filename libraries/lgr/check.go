@@ -0,0 +1,63 @@
+package lgr
+
+import "context"
+
+// CheckedEntry is a log that has been checked against the minimum level of a Logger and is ready to
+// be written with its fields. It is created by Logger.Check.
+type CheckedEntry struct {
+	logger  *Logger
+	level   Level
+	message string
+}
+
+// Check returns a CheckedEntry if a log at the given level will be written by the Logger, or nil if it
+// will not. This allows fields that are expensive to construct to only be built when they are needed:
+//
+//	if ce := logger.Check(lgr.DebugLevel, "my debug message"); ce != nil {
+//		ce.Write(lgr.Str("state", expensiveDump()))
+//	}
+//
+// A CheckedEntry is always returned for PanicLevel and FatalLevel, even when the Logger is nil or the
+// level is disabled, so that writing it panics or exits in the same way as Logger.Panic and Logger.Fatal.
+func (l *Logger) Check(level Level, msg string) *CheckedEntry {
+	if !l.Enabled(level) && level < PanicLevel {
+		return nil
+	}
+
+	return &CheckedEntry{logger: l, level: level, message: msg}
+}
+
+// Write writes the log with the given fields. As with the level methods of the Logger, writing a log at
+// PanicLevel panics with the message and writing a log at FatalLevel exits the application, whether or
+// not the log was written. Calling Write on a nil CheckedEntry is a no-op.
+func (ce *CheckedEntry) Write(fields ...Field) {
+	if ce == nil {
+		return
+	}
+
+	ce.logger.log(context.Background(), ce.level, ce.message, fields)
+
+	switch ce.level { //nolint: exhaustive // Only panic and fatal stop the flow of the program.
+	case PanicLevel:
+		panic(ce.message)
+	case FatalLevel:
+		ce.logger.exit()
+	}
+}
+
+// WriteCtx writes the log with the given fields in the same way as Write. Any fields carried by ctx
+// (see ContextFields) are added before the given fields.
+func (ce *CheckedEntry) WriteCtx(ctx context.Context, fields ...Field) {
+	if ce == nil {
+		return
+	}
+
+	ce.logger.log(ctx, ce.level, ce.message, mergeContextFields(ctx, fields))
+
+	switch ce.level { //nolint: exhaustive // Only panic and fatal stop the flow of the program.
+	case PanicLevel:
+		panic(ce.message)
+	case FatalLevel:
+		ce.logger.exit()
+	}
+}
@@ -0,0 +1,127 @@
+package lgr_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+func TestLoggerEnabled(t *testing.T) {
+	t.Parallel()
+
+	levelVar := lgr.NewLevelVar(lgr.WarnLevel)
+	logger := lgr.FromAdapter(&entryRecorder{}, lgr.WithMinLevel(levelVar))
+
+	assert.False(t, logger.Enabled(lgr.InfoLevel))
+	assert.True(t, logger.Enabled(lgr.WarnLevel))
+	assert.True(t, logger.Enabled(lgr.FatalLevel))
+
+	levelVar.Set(lgr.DebugLevel)
+	assert.True(t, logger.Enabled(lgr.DebugLevel))
+
+	assert.False(t, lgr.NewNop().Enabled(lgr.FatalLevel))
+	assert.False(t, lgr.FromAdapter(nil).Enabled(lgr.FatalLevel))
+}
+
+func TestLoggerCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns nil when the level is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		logger := lgr.FromAdapter(recorder, lgr.WithMinLevel(lgr.InfoLevel))
+
+		assert.Nil(t, logger.Check(lgr.DebugLevel, "my debug message"))
+		assert.Nil(t, lgr.NewNop().Check(lgr.ErrorLevel, "my error message"))
+
+		// Writing a nil CheckedEntry is a no-op so calls can be chained.
+		logger.Check(lgr.DebugLevel, "my debug message").Write(lgr.Str("strKey", "some string"))
+		logger.Check(lgr.DebugLevel, "my debug message").WriteCtx(context.Background())
+		assert.Empty(t, recorder.entries)
+	})
+
+	t.Run("writes the entry with the bound, context and given fields", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		logger := lgr.FromAdapter(recorder).Named("iam").With(lgr.Str("requestId", "abc-123"))
+		ctx := lgr.ContextFields(context.Background(), lgr.Str("userId", "user-1"))
+
+		ce := logger.Check(lgr.WarnLevel, "my warn message")
+		require.NotNil(t, ce)
+		ce.Write(lgr.Integer("intKey", 42))
+
+		logger.Check(lgr.ErrorLevel, "my error message").WriteCtx(ctx, lgr.Bool("boolKey", true))
+
+		require.Len(t, recorder.entries, 2)
+
+		assert.Equal(t, lgr.WarnLevel, recorder.entries[0].Level)
+		assert.Equal(t, "my warn message", recorder.entries[0].Message)
+		assert.Equal(t, "iam", recorder.entries[0].LoggerName)
+		assert.Equal(t, []lgr.Field{lgr.Str("requestId", "abc-123"), lgr.Integer("intKey", 42)}, recorder.entries[0].Fields)
+
+		assert.Equal(t, lgr.ErrorLevel, recorder.entries[1].Level)
+		assert.Equal(t, ctx, recorder.entries[1].Context)
+		assert.Equal(t, []lgr.Field{
+			lgr.Str("requestId", "abc-123"),
+			lgr.Str("userId", "user-1"),
+			lgr.Bool("boolKey", true),
+		}, recorder.entries[1].Fields)
+	})
+
+	t.Run("panics after writing a panic entry", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		logger := lgr.FromAdapter(recorder)
+
+		assert.PanicsWithValue(t, "my panic message", func() {
+			logger.Check(lgr.PanicLevel, "my panic message").Write()
+		})
+		assert.Len(t, recorder.entries, 1)
+	})
+
+	t.Run("exits after writing a fatal entry", func(t *testing.T) {
+		t.Parallel()
+
+		var exitCode int
+
+		recorder := &entryRecorder{}
+		logger := lgr.FromAdapter(recorder, lgr.WithExitFunc(func(code int) { exitCode = code }))
+
+		logger.Check(lgr.FatalLevel, "my fatal message").Write()
+
+		assert.Len(t, recorder.entries, 1)
+		assert.Equal(t, 1, exitCode)
+	})
+
+	t.Run("panics and exits when the level is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		var exitCode int
+
+		recorder := &entryRecorder{}
+		logger := lgr.FromAdapter(
+			recorder,
+			lgr.WithMinLevel(lgr.NewLevelVar(lgr.FatalLevel+1)),
+			lgr.WithExitFunc(func(code int) { exitCode = code }),
+		)
+
+		logger.Check(lgr.FatalLevel, "my fatal message").Write()
+		assert.Equal(t, 1, exitCode)
+
+		assert.PanicsWithValue(t, "my panic message", func() {
+			logger.Check(lgr.PanicLevel, "my panic message").WriteCtx(context.Background())
+		})
+		assert.PanicsWithValue(t, "my nil panic message", func() {
+			var nilLogger *lgr.Logger
+			nilLogger.Check(lgr.PanicLevel, "my nil panic message").Write()
+		})
+		assert.Empty(t, recorder.entries)
+	})
+}
@@ -0,0 +1,539 @@
+package lgr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidConfig is returned, wrapped in a *ConfigError, when a setting of a Config is invalid.
+var ErrInvalidConfig = errors.New("invalid value")
+
+// ConfigError is returned by NewFromConfig and NewFromEnv when a setting is invalid. It names the key of
+// the setting so that the configuration can be fixed without reading the code, e.g. "outputs[1].level"
+// for a Config or "IAM_LOG_LEVEL" for an environment variable.
+type ConfigError struct {
+	// Key is the key of the invalid setting.
+	Key string
+	// Err describes why the setting is invalid.
+	Err error
+}
+
+// Error returns the key of the invalid setting and why it is invalid.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid log config %s: %v", e.Key, e.Err)
+}
+
+// Unwrap returns the reason that the setting is invalid.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// Config configures a Logger created with NewFromConfig. It can be decoded from JSON or YAML, such as
+// the values of a Helm chart, with the keys given by its tags. Durations are strings as accepted by
+// time.ParseDuration, e.g. "1s", and levels are the names of the levels, e.g. "info".
+type Config struct {
+	// Level is the minimum level that logs are written at. The default is "debug".
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+	// Format is the format of the logs written to stderr, or the default format of the Outputs. The
+	// default is "console" when the output is a terminal and "json" otherwise.
+	Format Format `json:"format,omitempty" yaml:"format,omitempty"`
+	// Outputs are the destinations that logs are written to. The default is stderr.
+	Outputs []OutputSpec `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	// Caller adds the location of the code that wrote each log. See WithCaller.
+	Caller bool `json:"caller,omitempty" yaml:"caller,omitempty"`
+	// StackTraceLevel adds a stack trace to the logs written at or above the level. See WithStackTraceAt.
+	StackTraceLevel string `json:"stackTraceLevel,omitempty" yaml:"stackTraceLevel,omitempty"`
+	// Sampling limits the number of logs written with the same level and message. See WithSampling.
+	Sampling *SamplingSpec `json:"sampling,omitempty" yaml:"sampling,omitempty"`
+	// Redaction are the rules that fields are redacted with. See WithRedaction.
+	Redaction []RedactionSpec `json:"redaction,omitempty" yaml:"redaction,omitempty"`
+}
+
+// OutputSpec configures an Output of a Config.
+type OutputSpec struct {
+	// Path is the path of the file that logs are written to, stdout or stderr, or the address of the
+	// server for the "gelf" format.
+	Path string `json:"path" yaml:"path"`
+	// Level is the minimum level of the logs written to the output. The default is every level.
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+	// Format is the format of the logs written to the output. The default is the Format of the Config.
+	Format Format `json:"format,omitempty" yaml:"format,omitempty"`
+	// Rotation configures the rotation of the file at Path.
+	Rotation RotationSpec `json:"rotation,omitempty" yaml:"rotation,omitempty"`
+}
+
+// RotationSpec configures the Rotation of an OutputSpec.
+type RotationSpec struct {
+	// MaxSize is the size in bytes that the file can grow to before it is rotated.
+	MaxSize int64 `json:"maxSize,omitempty" yaml:"maxSize,omitempty"`
+	// MaxAge is how long logs are written to the file before it is rotated, e.g. "24h".
+	MaxAge string `json:"maxAge,omitempty" yaml:"maxAge,omitempty"`
+	// MaxBackups is the number of rotated files to keep.
+	MaxBackups int `json:"maxBackups,omitempty" yaml:"maxBackups,omitempty"`
+	// Compress enables gzip compression of rotated files.
+	Compress bool `json:"compress,omitempty" yaml:"compress,omitempty"`
+	// ReopenOnSIGHUP reopens the file when the process receives SIGHUP. See Rotation.ReopenOnSIGHUP.
+	ReopenOnSIGHUP bool `json:"reopenOnSighup,omitempty" yaml:"reopenOnSighup,omitempty"`
+}
+
+// SamplingSpec configures the sampling of a Config. See SamplingConfig.
+type SamplingSpec struct {
+	// Interval is the period after which the counts are reset, e.g. "1s".
+	Interval string `json:"interval" yaml:"interval"`
+	// First is the number of entries with the same level and message that are written each interval.
+	First int `json:"first" yaml:"first"`
+	// Thereafter is the rate at which entries are written once First has been reached.
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+}
+
+// RedactionSpec configures a RedactionRule of a Config.
+type RedactionSpec struct {
+	// Keys are the field keys that are redacted.
+	Keys []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+	// KeyPatterns are glob patterns that are matched against the field keys.
+	KeyPatterns []string `json:"keyPatterns,omitempty" yaml:"keyPatterns,omitempty"`
+	// ValuePatterns are regular expressions that are matched against the string values of fields.
+	ValuePatterns []string `json:"valuePatterns,omitempty" yaml:"valuePatterns,omitempty"`
+	// Mode is how the matched values are redacted, one of "mask", "hash" or "drop". The default is "mask".
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+}
+
+// NewFromConfig validates the config and creates a Logger with New from it. The options are applied
+// after those of the config so that code can add to, or override, the settings of the config. A
+// *ConfigError naming the key of the first invalid setting is returned when the config is invalid.
+func NewFromConfig(config Config, opts ...Option) (*Logger, error) {
+	configOpts, err := config.options()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(append(configOpts, opts...)...)
+}
+
+// NewFromEnv creates a Logger with NewFromConfig from the environment variables with the given prefix.
+// For the prefix "IAM" the variables are:
+//
+//	IAM_LOG_LEVEL                  the minimum level, e.g. info
+//	IAM_LOG_FORMAT                 the format, e.g. json
+//	IAM_LOG_OUTPUT                 comma separated paths that logs are written to, e.g. stderr,/var/log/iam.log
+//	IAM_LOG_CALLER                 true to add the caller of each log
+//	IAM_LOG_STACKTRACE_LEVEL       the level that stack traces are added at, e.g. error
+//	IAM_LOG_SAMPLING_INTERVAL      enables sampling with the interval, e.g. 1s
+//	IAM_LOG_SAMPLING_FIRST         the number of logs written each interval
+//	IAM_LOG_SAMPLING_THEREAFTER    the rate that logs are written at after the first
+//	IAM_LOG_REDACT_KEYS            comma separated field keys that are redacted
+//	IAM_LOG_REDACT_KEY_PATTERNS    comma separated glob patterns of field keys that are redacted
+//	IAM_LOG_REDACT_MODE            how fields are redacted, one of mask, hash or drop
+//
+// Without a prefix the variables start with LOG_. Unset and empty variables use the defaults of Config.
+// A *ConfigError naming the variable is returned when a variable is invalid.
+func NewFromEnv(prefix string, opts ...Option) (*Logger, error) {
+	env := newConfigEnv(prefix, os.LookupEnv)
+
+	config, err := env.config()
+	if err != nil {
+		return nil, err
+	}
+
+	configOpts, err := config.options()
+	if err != nil {
+		var configErr *ConfigError
+		if errors.As(err, &configErr) {
+			configErr.Key = env.variable(configErr.Key)
+		}
+
+		return nil, err
+	}
+
+	return New(append(configOpts, opts...)...)
+}
+
+// options validates the config and returns the options that configure a Logger with it.
+func (c Config) options() ([]Option, error) { //nolint: cyclop,funlen // Easier to read each setting in turn.
+	var opts []Option
+
+	if c.Level != "" {
+		level, err := parseConfigLevel("level", c.Level)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, WithMinLevel(level))
+	}
+
+	if err := validateFormat(c.Format); err != nil {
+		return nil, &ConfigError{Key: "format", Err: err}
+	}
+
+	if c.Format == FormatGELF && len(c.Outputs) == 0 {
+		// Logs are written to stderr without outputs, which is not the address of a GELF server.
+		return nil, &ConfigError{
+			Key: "format",
+			Err: fmt.Errorf("%w: gelf requires an output with a udp:// or tcp:// path", ErrInvalidConfig),
+		}
+	}
+
+	if len(c.Outputs) == 0 {
+		opts = append(opts, WithFormat(c.Format))
+	}
+
+	outputs := make([]Output, len(c.Outputs))
+
+	for i, spec := range c.Outputs {
+		output, err := spec.output(fmt.Sprintf("outputs[%d]", i), c.Format)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs[i] = output
+	}
+
+	if len(outputs) > 0 {
+		opts = append(opts, WithOutputs(outputs...))
+	}
+
+	if c.Caller {
+		opts = append(opts, WithCaller())
+	}
+
+	if c.StackTraceLevel != "" {
+		level, err := parseConfigLevel("stackTraceLevel", c.StackTraceLevel)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, WithStackTraceAt(level))
+	}
+
+	if c.Sampling != nil {
+		sampling, err := c.Sampling.config("sampling")
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, WithSampling(sampling))
+	}
+
+	rules := make([]RedactionRule, len(c.Redaction))
+
+	for i, spec := range c.Redaction {
+		rule, err := spec.rule(fmt.Sprintf("redaction[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+
+		rules[i] = rule
+	}
+
+	if len(rules) > 0 {
+		opts = append(opts, WithRedaction(rules...))
+	}
+
+	return opts, nil
+}
+
+func (s OutputSpec) output(key string, defaultFormat Format) (Output, error) {
+	output := Output{Path: s.Path, MinLevel: nil, Format: s.Format, Rotation: Rotation{MaxSize: 0, MaxAge: 0, MaxBackups: 0, Compress: false, ReopenOnSIGHUP: false}}
+
+	if s.Path == "" {
+		return output, &ConfigError{Key: key + ".path", Err: fmt.Errorf("%w: must not be empty", ErrInvalidConfig)}
+	}
+
+	if s.Level != "" {
+		level, err := parseConfigLevel(key+".level", s.Level)
+		if err != nil {
+			return output, err
+		}
+
+		output.MinLevel = level
+	}
+
+	if output.Format == "" {
+		output.Format = defaultFormat
+	}
+
+	if err := validateFormat(output.Format); err != nil {
+		return output, &ConfigError{Key: key + ".format", Err: err}
+	}
+
+	if output.Format == FormatGELF {
+		if _, _, err := parseGELFAddress(s.Path); err != nil {
+			return output, &ConfigError{Key: key + ".path", Err: err}
+		}
+	}
+
+	rotation, err := s.Rotation.rotation(key + ".rotation")
+	if err != nil {
+		return output, err
+	}
+
+	output.Rotation = rotation
+
+	return output, nil
+}
+
+func (s RotationSpec) rotation(key string) (Rotation, error) {
+	rotation := Rotation{MaxSize: s.MaxSize, MaxAge: 0, MaxBackups: s.MaxBackups, Compress: s.Compress, ReopenOnSIGHUP: s.ReopenOnSIGHUP}
+
+	if s.MaxSize < 0 {
+		return rotation, &ConfigError{Key: key + ".maxSize", Err: fmt.Errorf("%w: must not be negative", ErrInvalidConfig)}
+	}
+
+	if s.MaxBackups < 0 {
+		return rotation, &ConfigError{Key: key + ".maxBackups", Err: fmt.Errorf("%w: must not be negative", ErrInvalidConfig)}
+	}
+
+	if s.MaxAge != "" {
+		maxAge, err := parseConfigDuration(key+".maxAge", s.MaxAge)
+		if err != nil {
+			return rotation, err
+		}
+
+		rotation.MaxAge = maxAge
+	}
+
+	return rotation, nil
+}
+
+func (s SamplingSpec) config(key string) (SamplingConfig, error) {
+	config := SamplingConfig{Interval: 0, First: s.First, Thereafter: s.Thereafter}
+
+	interval, err := parseConfigDuration(key+".interval", s.Interval)
+	if err != nil {
+		return config, err
+	}
+
+	config.Interval = interval
+
+	if s.First < 0 {
+		return config, &ConfigError{Key: key + ".first", Err: fmt.Errorf("%w: must not be negative", ErrInvalidConfig)}
+	}
+
+	if s.Thereafter < 0 {
+		return config, &ConfigError{Key: key + ".thereafter", Err: fmt.Errorf("%w: must not be negative", ErrInvalidConfig)}
+	}
+
+	if s.First == 0 && s.Thereafter == 0 {
+		return config, &ConfigError{
+			Key: key + ".first",
+			Err: fmt.Errorf("%w: first and thereafter must not both be zero as every log would be dropped", ErrInvalidConfig),
+		}
+	}
+
+	return config, nil
+}
+
+func (s RedactionSpec) rule(key string) (RedactionRule, error) {
+	rule := RedactionRule{Keys: s.Keys, KeyPatterns: s.KeyPatterns, ValuePatterns: nil, Mode: RedactMask}
+
+	for i, pattern := range s.KeyPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return rule, &ConfigError{Key: fmt.Sprintf("%s.keyPatterns[%d]", key, i), Err: fmt.Errorf("%w: %w", ErrInvalidConfig, err)}
+		}
+	}
+
+	for i, pattern := range s.ValuePatterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return rule, &ConfigError{Key: fmt.Sprintf("%s.valuePatterns[%d]", key, i), Err: fmt.Errorf("%w: %w", ErrInvalidConfig, err)}
+		}
+
+		rule.ValuePatterns = append(rule.ValuePatterns, compiled)
+	}
+
+	switch strings.ToLower(s.Mode) {
+	case "", "mask":
+		rule.Mode = RedactMask
+	case "hash":
+		rule.Mode = RedactHash
+	case "drop":
+		rule.Mode = RedactDrop
+	default:
+		return rule, &ConfigError{
+			Key: key + ".mode",
+			Err: fmt.Errorf("%w: %q is not one of mask, hash or drop", ErrInvalidConfig, s.Mode),
+		}
+	}
+
+	if len(s.Keys) == 0 && len(s.KeyPatterns) == 0 && len(s.ValuePatterns) == 0 {
+		return rule, &ConfigError{
+			Key: key + ".keys",
+			Err: fmt.Errorf("%w: one of keys, keyPatterns or valuePatterns must be set", ErrInvalidConfig),
+		}
+	}
+
+	return rule, nil
+}
+
+func parseConfigLevel(key string, value string) (Level, error) {
+	var level Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return level, &ConfigError{Key: key, Err: err}
+	}
+
+	return level, nil
+}
+
+func parseConfigDuration(key string, value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, &ConfigError{Key: key, Err: fmt.Errorf("%w: %w", ErrInvalidConfig, err)}
+	}
+
+	if duration <= 0 {
+		return 0, &ConfigError{Key: key, Err: fmt.Errorf("%w: must be positive", ErrInvalidConfig)}
+	}
+
+	return duration, nil
+}
+
+// configEnv reads a Config from environment variables. It records which variable each setting of the
+// Config was read from so that errors can name the variable rather than the key of the Config.
+type configEnv struct {
+	prefix    string
+	lookup    func(key string) (string, bool)
+	variables map[string]string
+}
+
+func newConfigEnv(prefix string, lookup func(key string) (string, bool)) *configEnv {
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+
+	return &configEnv{prefix: prefix + "LOG_", lookup: lookup, variables: make(map[string]string)}
+}
+
+func (e *configEnv) config() (Config, error) { //nolint: cyclop,funlen // Easier to read each variable in turn.
+	config := Config{
+		Level:           e.string("level", "LEVEL"),
+		Format:          Format(e.string("format", "FORMAT")),
+		Outputs:         nil,
+		Caller:          false,
+		StackTraceLevel: e.string("stackTraceLevel", "STACKTRACE_LEVEL"),
+		Sampling:        nil,
+		Redaction:       nil,
+	}
+
+	for i, outputPath := range e.list("", "OUTPUT") {
+		key := fmt.Sprintf("outputs[%d]", i)
+		e.variables[key+".path"] = e.prefix + "OUTPUT"
+		e.variables[key+".format"] = e.prefix + "FORMAT"
+
+		config.Outputs = append(config.Outputs, OutputSpec{
+			Path:     outputPath,
+			Level:    "",
+			Format:   "",
+			Rotation: RotationSpec{MaxSize: 0, MaxAge: "", MaxBackups: 0, Compress: false, ReopenOnSIGHUP: false},
+		})
+	}
+
+	caller, err := e.bool("caller", "CALLER")
+	if err != nil {
+		return config, err
+	}
+
+	config.Caller = caller
+
+	interval := e.string("sampling.interval", "SAMPLING_INTERVAL")
+
+	first, err := e.int("sampling.first", "SAMPLING_FIRST")
+	if err != nil {
+		return config, err
+	}
+
+	thereafter, err := e.int("sampling.thereafter", "SAMPLING_THEREAFTER")
+	if err != nil {
+		return config, err
+	}
+
+	// Sampling is enabled by any of its variables so that a missing interval is reported rather than ignored.
+	if interval != "" || first != 0 || thereafter != 0 {
+		config.Sampling = &SamplingSpec{Interval: interval, First: first, Thereafter: thereafter}
+	}
+
+	keys := e.list("redaction[0].keys", "REDACT_KEYS")
+	keyPatterns := e.list("redaction[0].keyPatterns", "REDACT_KEY_PATTERNS")
+	mode := e.string("redaction[0].mode", "REDACT_MODE")
+
+	for i := range keyPatterns {
+		e.variables[fmt.Sprintf("redaction[0].keyPatterns[%d]", i)] = e.prefix + "REDACT_KEY_PATTERNS"
+	}
+
+	// Redaction is enabled by any of its variables so that a mode without keys is reported rather than ignored.
+	if len(keys) > 0 || len(keyPatterns) > 0 || mode != "" {
+		config.Redaction = []RedactionSpec{{
+			Keys:          keys,
+			KeyPatterns:   keyPatterns,
+			ValuePatterns: nil,
+			Mode:          mode,
+		}}
+	}
+
+	return config, nil
+}
+
+// variable returns the name of the variable that the setting with the given key was read from. The key
+// is returned when the setting was not read from a variable.
+func (e *configEnv) variable(key string) string {
+	if variable, ok := e.variables[key]; ok {
+		return variable
+	}
+
+	return key
+}
+
+func (e *configEnv) string(key string, name string) string {
+	if key != "" {
+		e.variables[key] = e.prefix + name
+	}
+
+	value, _ := e.lookup(e.prefix + name)
+
+	return strings.TrimSpace(value)
+}
+
+func (e *configEnv) list(key string, name string) []string {
+	var values []string
+
+	for _, value := range strings.Split(e.string(key, name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func (e *configEnv) bool(key string, name string) (bool, error) {
+	value := e.string(key, name)
+	if value == "" {
+		return false, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &ConfigError{Key: e.prefix + name, Err: fmt.Errorf("%w: %q is not a bool", ErrInvalidConfig, value)}
+	}
+
+	return parsed, nil
+}
+
+func (e *configEnv) int(key string, name string) (int, error) {
+	value := e.string(key, name)
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &ConfigError{Key: e.prefix + name, Err: fmt.Errorf("%w: %q is not an integer", ErrInvalidConfig, value)}
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,301 @@
+package lgr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+func TestNewFromConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("configures the logger from json", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		allPath, errorPath := filepath.Join(dir, "all.log"), filepath.Join(dir, "error.log")
+
+		var config lgr.Config
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"level": "info",
+			"format": "logfmt",
+			"outputs": [
+				{"path": "`+allPath+`"},
+				{"path": "`+errorPath+`", "level": "error", "format": "json", "rotation": {"maxSize": 1048576, "maxAge": "24h"}}
+			],
+			"caller": true,
+			"stackTraceLevel": "fatal",
+			"sampling": {"interval": "1s", "first": 1, "thereafter": 0},
+			"redaction": [{"keys": ["password"]}, {"valuePatterns": ["hunter\\d"], "mode": "hash"}]
+		}`), &config))
+
+		logger, err := lgr.NewFromConfig(config)
+		require.NoError(t, err)
+
+		logger.Debug("my debug message")
+		logger.Info("my info message", lgr.Str("password", "hunter2"))
+		logger.Info("my info message")
+		logger.Error("my error message", lgr.Str("note", "hunter3"))
+		require.NoError(t, logger.Close())
+
+		all, err := os.ReadFile(allPath)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(string(all)), "\n")
+		require.Len(t, lines, 2, "debug logs are filtered and the repeated info log is sampled")
+		assert.Contains(t, lines[0], `level=info msg="my info message" caller=`)
+		assert.Contains(t, lines[0], "password=[REDACTED]")
+		assert.Contains(t, lines[1], "note=sha256:")
+
+		errs, err := os.ReadFile(errorPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(errs), `"message":"my error message"`)
+		assert.NotContains(t, string(errs), "my info message")
+	})
+
+	t.Run("applies options after the config", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "app.log")
+
+		logger, err := lgr.NewFromConfig(
+			lgr.Config{Level: "error", Outputs: []lgr.OutputSpec{{Path: path, Format: lgr.FormatLogfmt}}}, //nolint: exhaustruct // Only the settings under test.
+			lgr.WithMinLevel(lgr.InfoLevel),
+		)
+		require.NoError(t, err)
+
+		logger.Info("my info message")
+		require.NoError(t, logger.Close())
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), "my info message")
+	})
+
+	testCases := map[string]struct {
+		config  lgr.Config
+		wantKey string
+		wantErr error
+	}{
+		"unknown level": {
+			config:  lgr.Config{Level: "verbose"}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "level",
+			wantErr: lgr.ErrUnknownLevel,
+		},
+		"unknown format": {
+			config:  lgr.Config{Format: "xml"}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "format",
+			wantErr: lgr.ErrUnknownFormat,
+		},
+		"output without a path": {
+			config:  lgr.Config{Outputs: []lgr.OutputSpec{{Path: "stderr"}, {Path: ""}}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "outputs[1].path",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"unknown output level": {
+			config:  lgr.Config{Outputs: []lgr.OutputSpec{{Path: "stderr", Level: "loud"}}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "outputs[0].level",
+			wantErr: lgr.ErrUnknownLevel,
+		},
+		"unknown output format": {
+			config:  lgr.Config{Outputs: []lgr.OutputSpec{{Path: "stderr", Format: "xml"}}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "outputs[0].format",
+			wantErr: lgr.ErrUnknownFormat,
+		},
+		"gelf format without outputs": {
+			config:  lgr.Config{Format: lgr.FormatGELF}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "format",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"gelf output without a network address": {
+			config:  lgr.Config{Format: lgr.FormatGELF, Outputs: []lgr.OutputSpec{{Path: "stderr"}}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "outputs[0].path",
+			wantErr: lgr.ErrInvalidGELFAddress,
+		},
+		"invalid rotation age": {
+			config: lgr.Config{Outputs: []lgr.OutputSpec{ //nolint: exhaustruct // Only the setting under test.
+				{Path: "app.log", Rotation: lgr.RotationSpec{MaxAge: "a day"}}, //nolint: exhaustruct // Only the setting under test.
+			}},
+			wantKey: "outputs[0].rotation.maxAge",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"negative rotation size": {
+			config: lgr.Config{Outputs: []lgr.OutputSpec{ //nolint: exhaustruct // Only the setting under test.
+				{Path: "app.log", Rotation: lgr.RotationSpec{MaxSize: -1}}, //nolint: exhaustruct // Only the setting under test.
+			}},
+			wantKey: "outputs[0].rotation.maxSize",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"unknown stack trace level": {
+			config:  lgr.Config{StackTraceLevel: "all"}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "stackTraceLevel",
+			wantErr: lgr.ErrUnknownLevel,
+		},
+		"missing sampling interval": {
+			config:  lgr.Config{Sampling: &lgr.SamplingSpec{Interval: "", First: 1, Thereafter: 1}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "sampling.interval",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"negative sampling interval": {
+			config:  lgr.Config{Sampling: &lgr.SamplingSpec{Interval: "-1s", First: 1, Thereafter: 1}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "sampling.interval",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"negative sampling thereafter": {
+			config:  lgr.Config{Sampling: &lgr.SamplingSpec{Interval: "1s", First: 1, Thereafter: -1}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "sampling.thereafter",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"sampling that drops every log": {
+			config:  lgr.Config{Sampling: &lgr.SamplingSpec{Interval: "1s", First: 0, Thereafter: 0}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "sampling.first",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"malformed redaction key pattern": {
+			config:  lgr.Config{Redaction: []lgr.RedactionSpec{{KeyPatterns: []string{"*token", "[pass"}}}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "redaction[0].keyPatterns[1]",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"malformed redaction value pattern": {
+			config: lgr.Config{Redaction: []lgr.RedactionSpec{ //nolint: exhaustruct // Only the setting under test.
+				{Keys: []string{"password"}},             //nolint: exhaustruct // Only the setting under test.
+				{ValuePatterns: []string{`card-(\d{4}`}}, //nolint: exhaustruct // Only the setting under test.
+			}},
+			wantKey: "redaction[1].valuePatterns[0]",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"redaction without keys": {
+			config:  lgr.Config{Redaction: []lgr.RedactionSpec{{Mode: "drop"}}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "redaction[0].keys",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+		"unknown redaction mode": {
+			config:  lgr.Config{Redaction: []lgr.RedactionSpec{{Keys: []string{"password"}, Mode: "shred"}}}, //nolint: exhaustruct // Only the setting under test.
+			wantKey: "redaction[0].mode",
+			wantErr: lgr.ErrInvalidConfig,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := lgr.NewFromConfig(tc.config)
+			assert.Nil(t, logger)
+			assert.ErrorIs(t, err, tc.wantErr)
+
+			var configErr *lgr.ConfigError
+			require.True(t, errors.As(err, &configErr), "error is not a *lgr.ConfigError: %v", err)
+			assert.Equal(t, tc.wantKey, configErr.Key)
+			assert.ErrorContains(t, err, "invalid log config "+tc.wantKey+": ")
+		})
+	}
+}
+
+func TestNewFromEnv(t *testing.T) { //nolint: paralleltest // Environment variables can not be set during a parallel test.
+	t.Run("configures the logger from the environment", func(t *testing.T) { //nolint: paralleltest // Sets environment variables.
+		path := filepath.Join(t.TempDir(), "app.log")
+
+		t.Setenv("LGRTEST_LOG_LEVEL", "WARN")
+		t.Setenv("LGRTEST_LOG_FORMAT", "logfmt")
+		t.Setenv("LGRTEST_LOG_OUTPUT", path)
+		t.Setenv("LGRTEST_LOG_REDACT_KEYS", "password, token")
+		t.Setenv("LGRTEST_LOG_REDACT_MODE", "drop")
+
+		logger, err := lgr.NewFromEnv("LGRTEST")
+		require.NoError(t, err)
+
+		logger.Info("my info message")
+		logger.Warn("my warn message", lgr.Str("token", "abc"), lgr.Str("user", "1"))
+		require.NoError(t, logger.Close())
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Regexp(t, `^time=\S+ level=warn msg="my warn message" user=1\n$`, string(contents))
+	})
+
+	testCases := map[string]struct {
+		env     map[string]string
+		wantKey string
+	}{
+		"unknown level": {
+			env:     map[string]string{"LGRTEST_LOG_LEVEL": "verbose"},
+			wantKey: "LGRTEST_LOG_LEVEL",
+		},
+		"unknown format": {
+			env:     map[string]string{"LGRTEST_LOG_FORMAT": "xml", "LGRTEST_LOG_OUTPUT": "stderr"},
+			wantKey: "LGRTEST_LOG_FORMAT",
+		},
+		"gelf format without an output": {
+			env:     map[string]string{"LGRTEST_LOG_FORMAT": "gelf"},
+			wantKey: "LGRTEST_LOG_FORMAT",
+		},
+		"gelf format with an output that is not a network address": {
+			env:     map[string]string{"LGRTEST_LOG_FORMAT": "gelf", "LGRTEST_LOG_OUTPUT": "udp://graylog:12201,stderr"},
+			wantKey: "LGRTEST_LOG_OUTPUT",
+		},
+		"invalid caller": {
+			env:     map[string]string{"LGRTEST_LOG_CALLER": "sometimes"},
+			wantKey: "LGRTEST_LOG_CALLER",
+		},
+		"unknown stack trace level": {
+			env:     map[string]string{"LGRTEST_LOG_STACKTRACE_LEVEL": "all"},
+			wantKey: "LGRTEST_LOG_STACKTRACE_LEVEL",
+		},
+		"sampling without an interval": {
+			env:     map[string]string{"LGRTEST_LOG_SAMPLING_FIRST": "10"},
+			wantKey: "LGRTEST_LOG_SAMPLING_INTERVAL",
+		},
+		"sampling that drops every log": {
+			env:     map[string]string{"LGRTEST_LOG_SAMPLING_INTERVAL": "1s"},
+			wantKey: "LGRTEST_LOG_SAMPLING_FIRST",
+		},
+		"invalid sampling rate": {
+			env:     map[string]string{"LGRTEST_LOG_SAMPLING_INTERVAL": "1s", "LGRTEST_LOG_SAMPLING_THEREAFTER": "often"},
+			wantKey: "LGRTEST_LOG_SAMPLING_THEREAFTER",
+		},
+		"malformed redaction key pattern": {
+			env:     map[string]string{"LGRTEST_LOG_REDACT_KEY_PATTERNS": "*token,[pass"},
+			wantKey: "LGRTEST_LOG_REDACT_KEY_PATTERNS",
+		},
+		"unknown redaction mode without keys": {
+			env:     map[string]string{"LGRTEST_LOG_REDACT_MODE": "shred"},
+			wantKey: "LGRTEST_LOG_REDACT_MODE",
+		},
+		"redaction mode without keys": {
+			env:     map[string]string{"LGRTEST_LOG_REDACT_MODE": "hash"},
+			wantKey: "LGRTEST_LOG_REDACT_KEYS",
+		},
+		"unknown redaction mode": {
+			env:     map[string]string{"LGRTEST_LOG_REDACT_KEYS": "password", "LGRTEST_LOG_REDACT_MODE": "shred"},
+			wantKey: "LGRTEST_LOG_REDACT_MODE",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) { //nolint: paralleltest // Sets environment variables.
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			logger, err := lgr.NewFromEnv("LGRTEST_")
+			assert.Nil(t, logger)
+
+			var configErr *lgr.ConfigError
+			require.True(t, errors.As(err, &configErr), "error is not a *lgr.ConfigError: %v", err)
+			assert.Equal(t, tc.wantKey, configErr.Key)
+		})
+	}
+}
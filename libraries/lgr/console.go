@@ -0,0 +1,141 @@
+package lgr
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-isatty"
+)
+
+// consoleMessageWidth is the width that messages are padded to so that the fields of consecutive
+// logs line up.
+const consoleMessageWidth = 40
+
+// consoleTimeFormat is the format of the timestamp written by the console encoder.
+const consoleTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// ANSI escape codes used to colour console output.
+const (
+	colorReset   = "\x1b[0m"
+	colorBold    = "\x1b[1m"
+	colorFaint   = "\x1b[2m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+)
+
+// WithFormat sets the Format of the logs written to the output set by WithOutputPath. Outputs configured
+// with WithOutputs set their own Format. By default FormatConsole is used when the output is a terminal
+// and FormatJSON is used otherwise.
+func WithFormat(format Format) Option {
+	return func(l *Logger) {
+		l.format = format
+	}
+}
+
+// consoleEncoder encodes entries in a human-readable format for local development, e.g.
+//
+//	2022-03-05T00:00:00.000Z INF iam.identity > my info message    requestId=abc-123
+type consoleEncoder struct {
+	color bool
+}
+
+func (e consoleEncoder) encode(buf *bytes.Buffer, entry Entry) {
+	if !entry.Time.IsZero() {
+		e.colorize(buf, colorFaint, entry.Time.Format(consoleTimeFormat))
+		buf.WriteByte(' ')
+	}
+
+	e.colorize(buf, consoleLevelColor(entry.Level), consoleLevelTag(entry.Level))
+	buf.WriteByte(' ')
+
+	if entry.LoggerName != "" {
+		e.colorize(buf, colorBold, entry.LoggerName)
+		buf.WriteString(" > ")
+	}
+
+	buf.WriteString(entry.Message)
+
+	fields := entry.Fields
+	if entry.Caller.Defined {
+		fields = append(fields[:len(fields):len(fields)], Str("caller", entry.Caller.String()))
+	}
+
+	if len(fields) > 0 {
+		if padding := consoleMessageWidth - utf8.RuneCountInString(entry.Message); padding > 0 {
+			buf.WriteString(strings.Repeat(" ", padding))
+		}
+
+		for _, kv := range flattenFields("", fields) {
+			buf.WriteByte(' ')
+			e.colorize(buf, colorCyan, kv.key+"=")
+			buf.WriteString(kv.value)
+		}
+	}
+
+	buf.WriteByte('\n')
+
+	for _, frame := range entry.Stack {
+		buf.WriteString("\t")
+		buf.WriteString(frame)
+		buf.WriteByte('\n')
+	}
+}
+
+func (e consoleEncoder) colorize(buf *bytes.Buffer, color, s string) {
+	if !e.color {
+		buf.WriteString(s)
+
+		return
+	}
+
+	buf.WriteString(color)
+	buf.WriteString(s)
+	buf.WriteString(colorReset)
+}
+
+func consoleLevelTag(level Level) string {
+	switch level {
+	case DebugLevel:
+		return "DBG"
+	case InfoLevel:
+		return "INF"
+	case WarnLevel:
+		return "WRN"
+	case ErrorLevel:
+		return "ERR"
+	case PanicLevel:
+		return "PNC"
+	case FatalLevel:
+		return "FTL"
+	default:
+		return "???"
+	}
+}
+
+func consoleLevelColor(level Level) string {
+	switch level {
+	case DebugLevel:
+		return colorMagenta
+	case InfoLevel:
+		return colorGreen
+	case WarnLevel:
+		return colorYellow
+	case ErrorLevel, PanicLevel, FatalLevel:
+		return colorBold + colorRed
+	default:
+		return colorBold
+	}
+}
+
+// isTerminal reports whether the writer is a terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+
+	return ok && (isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd()))
+}
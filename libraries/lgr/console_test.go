@@ -0,0 +1,73 @@
+package lgr
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsoleEncoder(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		entry Entry
+		color bool
+		want  string
+	}{
+		"writes the level and message": {
+			entry: Entry{Time: now, Level: InfoLevel, Message: "my info message"},
+			want:  "2022-03-05T00:00:00.000Z INF my info message\n",
+		},
+		"writes fields as aligned key value pairs": {
+			entry: Entry{
+				Time:       now,
+				Level:      WarnLevel,
+				Message:    "my warn message",
+				LoggerName: "iam.identity",
+				Fields: []Field{
+					Str("requestId", "abc-123"),
+					Str("reason", "bad request"),
+					Err(errors.New("some error")),
+					Duration("took", time.Second),
+					Group("user", Integer("id", 1), Strs("roles", []string{"admin", "user"})),
+					Secret("password", "hunter2"),
+				},
+			},
+			want: "2022-03-05T00:00:00.000Z WRN iam.identity > my warn message                         " +
+				` requestId=abc-123 reason="bad request" error="some error" took=1s user.id=1 user.roles=[admin,user]` +
+				" password=[REDACTED]\n",
+		},
+		"writes the caller and stack": {
+			entry: Entry{
+				Level:   ErrorLevel,
+				Message: "my error message",
+				Caller:  Caller{Defined: true, PC: 1, Function: "main.main", File: "/app/main.go", Line: 42},
+				Stack:   []string{"main.main /app/main.go:42"},
+			},
+			want: "ERR my error message                         caller=/app/main.go:42\n\tmain.main /app/main.go:42\n",
+		},
+		"colourises the output": {
+			entry: Entry{Level: ErrorLevel, Message: "my error message", Fields: []Field{Bool("boolKey", true)}},
+			color: true,
+			want:  "\x1b[1m\x1b[31mERR\x1b[0m my error message                         \x1b[36mboolKey=\x1b[0mtrue\n",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			consoleEncoder{color: tc.color}.encode(&buf, tc.entry)
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+}
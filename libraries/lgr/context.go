@@ -0,0 +1,57 @@
+package lgr
+
+import "context"
+
+// loggerContextKey is the key used to carry a *Logger within a context.Context.
+type loggerContextKey struct{}
+
+// fieldsContextKey is the key used to carry []Field within a context.Context.
+type fieldsContextKey struct{}
+
+// IntoContext returns a copy of ctx that carries the given Logger so that it can be retrieved
+// further down the call stack with FromContext.
+func IntoContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the Logger carried by ctx. If ctx does not carry a Logger then the no-op
+// Logger returned by NewNop is returned so that the result is always safe to use.
+func FromContext(ctx context.Context) *Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*Logger); ok {
+		return logger
+	}
+
+	return NewNop()
+}
+
+// ContextFields returns a copy of ctx that carries the given fields in addition to any fields
+// that are already carried by ctx. Fields carried by a context.Context are added to every log
+// written with one of the Ctx suffixed Logger methods, such as Logger.InfoCtx.
+func ContextFields(ctx context.Context, fields ...Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+
+	carried := fieldsFromContext(ctx)
+
+	return context.WithValue(ctx, fieldsContextKey{}, append(carried[:len(carried):len(carried)], fields...))
+}
+
+// fieldsFromContext returns the fields carried by ctx or nil if ctx does not carry any fields.
+func fieldsFromContext(ctx context.Context) []Field {
+	if fields, ok := ctx.Value(fieldsContextKey{}).([]Field); ok {
+		return fields
+	}
+
+	return nil
+}
+
+// mergeContextFields returns the fields carried by ctx followed by the given fields.
+func mergeContextFields(ctx context.Context, fields []Field) []Field {
+	carried := fieldsFromContext(ctx)
+	if len(carried) == 0 {
+		return fields
+	}
+
+	return append(carried[:len(carried):len(carried)], fields...)
+}
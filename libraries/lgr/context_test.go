@@ -0,0 +1,90 @@
+package lgr_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+func TestIntoContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("logger can be retrieved from the context", func(t *testing.T) {
+		t.Parallel()
+
+		logger, _ := lgrtest.New()
+
+		assert.Same(t, logger, lgr.FromContext(lgr.IntoContext(context.Background(), logger)))
+	})
+
+	t.Run("returns a nop logger when the context does not carry a logger", func(t *testing.T) {
+		t.Parallel()
+
+		logger := lgr.FromContext(context.Background())
+		assert.Nil(t, logger)
+
+		// No assertions as nil logger would panic on receiver normally causing the test to fail.
+		logger.InfoCtx(context.Background(), "my info message")
+	})
+}
+
+func TestLoggerCtx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("adds context fields to each level", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		ctx := lgr.ContextFields(context.Background(), lgr.Str("requestId", "abc-123"))
+
+		logger.DebugCtx(ctx, "my debug message", lgr.Integer("intKey", 123))
+		logger.InfoCtx(ctx, "my info message", lgr.Integer("intKey", 123))
+		logger.WarnCtx(ctx, "my warn message", lgr.Integer("intKey", 123))
+		logger.ErrorCtx(ctx, "my error message", lgr.Integer("intKey", 123))
+
+		logs := entries.All()
+		assert.Len(t, logs, 4)
+
+		lgrtest.AssertFullEntry(t, logs[0], lgr.DebugLevel, "my debug message", lgr.Str("requestId", "abc-123"), lgr.Integer("intKey", 123))
+		lgrtest.AssertFullEntry(t, logs[1], lgr.InfoLevel, "my info message", lgr.Str("requestId", "abc-123"), lgr.Integer("intKey", 123))
+		lgrtest.AssertFullEntry(t, logs[2], lgr.WarnLevel, "my warn message", lgr.Str("requestId", "abc-123"), lgr.Integer("intKey", 123))
+		lgrtest.AssertFullEntry(t, logs[3], lgr.ErrorLevel, "my error message", lgr.Str("requestId", "abc-123"), lgr.Integer("intKey", 123))
+	})
+
+	t.Run("accumulates fields from parent contexts", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		parent := lgr.ContextFields(context.Background(), lgr.Str("requestId", "abc-123"))
+		ctx := lgr.ContextFields(parent, lgr.Str("route", "/identities"))
+
+		logger.With(lgr.Str("service", "iam")).InfoCtx(ctx, "my info message")
+		logger.InfoCtx(parent, "my parent message")
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.InfoLevel,
+			"my info message",
+			lgr.Str("service", "iam"),
+			lgr.Str("requestId", "abc-123"),
+			lgr.Str("route", "/identities"),
+		)
+		lgrtest.AssertFullEntry(t, entries.Idx(1), lgr.InfoLevel, "my parent message", lgr.Str("requestId", "abc-123"))
+	})
+
+	t.Run("works with a logger retrieved from the context", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		ctx := lgr.ContextFields(lgr.IntoContext(context.Background(), logger), lgr.Str("identityId", "def-456"))
+
+		lgr.FromContext(ctx).ErrorCtx(ctx, "my error message")
+
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.ErrorLevel, "my error message", lgr.Str("identityId", "def-456"))
+	})
+}
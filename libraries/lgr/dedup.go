@@ -0,0 +1,129 @@
+package lgr
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// repeatedFieldKey is the key of the field that reports how many times a duplicate entry was suppressed.
+const repeatedFieldKey = "repeated"
+
+// WithDedup configures the Logger created by New to suppress duplicate logs within the given window.
+// See DedupAdapter.
+func WithDedup(window time.Duration) Option {
+	return func(l *Logger) {
+		l.dedupWindow = window
+	}
+}
+
+// dedupEntry tracks the entries with the same level and message within a window.
+type dedupEntry struct {
+	last     Entry
+	repeated int
+	timer    *time.Timer
+}
+
+// DedupAdapter is an Adapter that collapses identical entries, those with the same level and message,
+// within a window. The first entry is written immediately and any duplicates within the window are
+// suppressed. When the window ends the last duplicate is written once with the number of suppressed
+// entries added to its fields with the key "repeated".
+type DedupAdapter struct {
+	adapter Adapter
+	window  time.Duration
+
+	mu      sync.Mutex
+	pending map[samplingKey]*dedupEntry
+}
+
+// NewDedupAdapter creates a new DedupAdapter that writes entries to the given Adapter, suppressing
+// duplicates within the window.
+func NewDedupAdapter(adapter Adapter, window time.Duration) *DedupAdapter {
+	return &DedupAdapter{
+		adapter: adapter,
+		window:  window,
+		mu:      sync.Mutex{},
+		pending: make(map[samplingKey]*dedupEntry),
+	}
+}
+
+// Adapt writes the log to the underlying Adapter unless it is a duplicate.
+func (d *DedupAdapter) Adapt(level Level, message string, fields ...Field) {
+	d.AdaptEntry(newEntry(level, message, fields))
+}
+
+// AdaptEntry writes the entry to the underlying Adapter unless it is a duplicate of an entry that was
+// written within the window.
+func (d *DedupAdapter) AdaptEntry(entry Entry) {
+	key := samplingKey{level: entry.Level, message: entry.Message}
+
+	d.mu.Lock()
+
+	if pending, ok := d.pending[key]; ok {
+		// The fields are copied as the caller is free to reuse the slice once the call returns.
+		entry.Fields = append([]Field(nil), entry.Fields...)
+		pending.last = entry
+		pending.repeated++
+		d.mu.Unlock()
+
+		return
+	}
+
+	pending := &dedupEntry{last: entry, repeated: 0, timer: nil}
+	pending.timer = time.AfterFunc(d.window, func() { d.flush(key, pending) })
+	d.pending[key] = pending
+	d.mu.Unlock()
+
+	adaptEntry(d.adapter, entry)
+}
+
+// Sync writes the suppressed duplicates of every pending entry and then syncs the underlying Adapter if it
+// implements Syncer.
+func (d *DedupAdapter) Sync(ctx context.Context) error {
+	d.mu.Lock()
+	pending := make(map[samplingKey]*dedupEntry, len(d.pending))
+
+	for key, entry := range d.pending {
+		pending[key] = entry
+	}
+	d.mu.Unlock()
+
+	for key, entry := range pending {
+		d.flush(key, entry)
+	}
+
+	return syncAdapter(ctx, d.adapter)
+}
+
+// Close writes the suppressed duplicates of every pending entry and then closes the underlying Adapter
+// if it implements io.Closer.
+func (d *DedupAdapter) Close() error {
+	if err := d.Sync(context.Background()); err != nil {
+		return err
+	}
+
+	return closeAdapter(d.adapter)
+}
+
+// flush ends the window of the pending entry with the given key, writing the last duplicate with the
+// repeated count if any duplicates were suppressed. Nothing is written if the window has already ended,
+// such as when the timer of a window that was flushed by Sync fires after a new window has started.
+func (d *DedupAdapter) flush(key samplingKey, pending *dedupEntry) {
+	d.mu.Lock()
+	ok := d.pending[key] == pending
+
+	if ok {
+		pending.timer.Stop()
+		delete(d.pending, key)
+	}
+	d.mu.Unlock()
+
+	if !ok || pending.repeated == 0 {
+		return
+	}
+
+	entry := pending.last
+	entry.Fields = append(entry.Fields, Integer(repeatedFieldKey, pending.repeated))
+
+	adaptEntry(d.adapter, entry)
+}
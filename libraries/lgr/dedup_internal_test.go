@@ -0,0 +1,40 @@
+package lgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupAdapterFlush(t *testing.T) {
+	t.Parallel()
+
+	t.Run("does not end a window restarted after a sync when the old timer fires", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &messageRecorder{messages: nil}
+		dedup := NewDedupAdapter(recorder, time.Hour)
+		key := samplingKey{level: InfoLevel, message: "first window"}
+
+		dedup.Adapt(InfoLevel, "first window")
+		old := dedup.pending[key]
+
+		require.NoError(t, dedup.Sync(context.Background()))
+
+		// Entries with the same key as the synced window start a new window.
+		dedup.Adapt(InfoLevel, "first window")
+		dedup.Adapt(InfoLevel, "first window")
+
+		// The timer of the synced window fires late, e.g. when it fired while Sync held the lock.
+		dedup.flush(key, old)
+
+		assert.Equal(t, []string{"first window", "first window"}, recorder.messages)
+		assert.Equal(t, 1, dedup.pending[key].repeated)
+
+		require.NoError(t, dedup.Close())
+		assert.Equal(t, []string{"first window", "first window", "first window"}, recorder.messages)
+	})
+}
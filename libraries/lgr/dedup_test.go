@@ -0,0 +1,66 @@
+package lgr_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+func TestDedupAdapter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("collapses duplicates into one entry with a repeated count", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		logger := lgr.FromAdapter(lgr.NewDedupAdapter(adapter, time.Hour))
+
+		logger.Info("my info message", lgr.Integer("i", 1))
+		logger.Info("my info message", lgr.Integer("i", 2))
+		logger.Warn("my info message")
+		logger.Info("my info message", lgr.Integer("i", 3))
+		logger.Info("my other message")
+
+		require.NoError(t, logger.Sync(context.Background()))
+		require.Len(t, entries.All(), 4)
+
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.InfoLevel, "my info message", lgr.Integer("i", 1))
+		lgrtest.AssertFullEntry(t, entries.Idx(1), lgr.WarnLevel, "my info message")
+		lgrtest.AssertFullEntry(t, entries.Idx(2), lgr.InfoLevel, "my other message")
+		lgrtest.AssertFullEntry(t, entries.Idx(3), lgr.InfoLevel, "my info message", lgr.Integer("i", 3), lgr.Integer("repeated", 2))
+	})
+
+	t.Run("writes the repeated count when the window ends", func(t *testing.T) {
+		t.Parallel()
+
+		gated := newGatedAdapter()
+		close(gated.gate)
+
+		dedup := lgr.NewDedupAdapter(gated, time.Millisecond)
+
+		dedup.Adapt(lgr.InfoLevel, "my info message")
+		dedup.Adapt(lgr.InfoLevel, "my info message")
+
+		assert.Eventually(t, func() bool { return len(gated.messages()) == 2 }, time.Second, time.Millisecond)
+		assert.Equal(t, []lgr.Field{lgr.Integer("repeated", 1)}, gated.entries[1].Fields)
+	})
+
+	t.Run("starts a new window once the previous window ends", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		dedup := lgr.NewDedupAdapter(adapter, time.Hour)
+
+		dedup.Adapt(lgr.InfoLevel, "my info message")
+		require.NoError(t, dedup.Close())
+		dedup.Adapt(lgr.InfoLevel, "my info message")
+
+		assert.Len(t, entries.All(), 2)
+	})
+}
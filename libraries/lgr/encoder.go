@@ -0,0 +1,245 @@
+package lgr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// EncoderConfig configures how the JSON, logfmt and GELF outputs of a Logger created with New write each log.
+// Each Logger owns its own config so that loggers with different settings can be used side by side.
+type EncoderConfig struct {
+	// TimestampKey, LevelKey, MessageKey and ContextKey are the keys that the timestamp, level, message
+	// and fields of each log are written under. Empty keys use the default of the format, which is
+	// "timestamp", "level", "message" and "context" for FormatJSON and "time", "level" and "msg" for
+	// FormatLogfmt. FormatLogfmt does not use the ContextKey as it writes the fields as top level keys.
+	TimestampKey string
+	LevelKey     string
+	MessageKey   string
+	ContextKey   string
+	// TimeFormat is the layout, as accepted by time.Time.Format, that timestamps are written with. It is
+	// also used for time fields written by FormatJSON. The default is time.RFC3339 for FormatJSON and
+	// time.RFC3339Nano for FormatLogfmt.
+	TimeFormat string
+	// DurationUnit is the unit that FormatJSON writes duration fields as a number of. The default is
+	// time.Millisecond.
+	DurationUnit time.Duration
+	// Host identifies the host that sent the logs written by FormatGELF. The default is the hostname.
+	Host string
+}
+
+// WithEncoderConfig sets the EncoderConfig of the JSON, logfmt and GELF outputs of the Logger created by New.
+func WithEncoderConfig(config EncoderConfig) Option {
+	return func(l *Logger) {
+		l.encoderConfig = config
+	}
+}
+
+// withDefaults returns the config with each unset setting replaced by the setting of the defaults.
+func (c EncoderConfig) withDefaults(defaults EncoderConfig) EncoderConfig {
+	if c.TimestampKey == "" {
+		c.TimestampKey = defaults.TimestampKey
+	}
+
+	if c.LevelKey == "" {
+		c.LevelKey = defaults.LevelKey
+	}
+
+	if c.MessageKey == "" {
+		c.MessageKey = defaults.MessageKey
+	}
+
+	if c.ContextKey == "" {
+		c.ContextKey = defaults.ContextKey
+	}
+
+	if c.TimeFormat == "" {
+		c.TimeFormat = defaults.TimeFormat
+	}
+
+	if c.DurationUnit <= 0 {
+		c.DurationUnit = defaults.DurationUnit
+	}
+
+	if c.Host == "" {
+		c.Host = defaults.Host
+	}
+
+	return c
+}
+
+// lineEncoder encodes an entry as a single line of text.
+type lineEncoder interface {
+	encode(buf *bytes.Buffer, entry Entry)
+}
+
+// outputCloser is an output that must be synced and closed, such as a file or network connection.
+type outputCloser interface {
+	Sync() error
+	Close() error
+}
+
+// lineAdapter is an Adapter that writes each entry to the output as encoded by its lineEncoder. Each
+// entry is written with a single call to Write.
+type lineAdapter struct {
+	mu      *sync.Mutex
+	output  io.Writer
+	closer  outputCloser
+	encoder lineEncoder
+}
+
+// newLineAdapter creates a lineAdapter that writes logs encoded by the encoder to the given output. The
+// closer should be set when the output is a file or connection so that it can be synced and closed.
+func newLineAdapter(output io.Writer, closer outputCloser, encoder lineEncoder) lineAdapter {
+	return lineAdapter{mu: &sync.Mutex{}, output: output, closer: closer, encoder: encoder}
+}
+
+func (a lineAdapter) Adapt(level Level, message string, fields ...Field) {
+	a.AdaptEntry(newEntry(level, message, fields))
+}
+
+func (a lineAdapter) AdaptEntry(entry Entry) {
+	var buf bytes.Buffer
+
+	a.encoder.encode(&buf, entry)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, _ = a.output.Write(buf.Bytes()) // There is nothing we can do with the error as Adapt does not return.
+}
+
+// Sync flushes the output. It is a no-op when writing to stdout or stderr.
+func (a lineAdapter) Sync(_ context.Context) error {
+	if a.closer == nil {
+		return nil
+	}
+
+	if err := a.closer.Sync(); err != nil {
+		return fmt.Errorf("syncing output: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the output. It is a no-op when writing to stdout or stderr.
+func (a lineAdapter) Close() error {
+	if a.closer == nil {
+		return nil
+	}
+
+	if err := a.closer.Close(); err != nil {
+		return fmt.Errorf("closing output: %w", err)
+	}
+
+	return nil
+}
+
+// keyValue is a field that has been flattened to a key and a formatted value.
+type keyValue struct {
+	key   string
+	value string
+}
+
+// flattenFields flattens the fields into key value pairs. Objects and groups are flattened with their
+// keys joined by a "." and arrays are formatted as a comma separated list within brackets.
+func flattenFields(prefix string, fields []Field) []keyValue {
+	kvs := make([]keyValue, 0, len(fields))
+
+	for _, field := range fields {
+		key := field.Key
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch field.Type { //nolint: exhaustive // All other types are formatted as a single value.
+		case ObjectType:
+			// Nil objects are formatted as a single value.
+			if object, ok := field.Interface.(ObjectMarshaler); ok {
+				kvs = append(kvs, flattenFields(key, object.MarshalLogObject())...)
+
+				continue
+			}
+		case GroupType:
+			kvs = append(kvs, flattenFields(key, field.Interface.([]Field))...) //nolint: forcetypeassert // We know the type.
+
+			continue
+		}
+
+		kvs = append(kvs, keyValue{key: key, value: formatFieldValue(field)})
+	}
+
+	return kvs
+}
+
+// formatFieldValue formats the value of a field as text. Strings are quoted when they contain spaces,
+// quotes, equals signs or control characters so that the output can be parsed.
+func formatFieldValue(field Field) string {
+	switch field.Type { //nolint: exhaustive // All other types are formatted via fmt.
+	case ArrayType:
+		// Nil arrays are formatted via fmt.
+		if array, ok := field.Interface.(ArrayMarshaler); ok {
+			elements := array.MarshalLogArray()
+			values := make([]string, len(elements))
+
+			for i, element := range elements {
+				if element.Type == ObjectType || element.Type == GroupType {
+					values[i] = formatNestedValue(element)
+				} else {
+					values[i] = formatFieldValue(element)
+				}
+			}
+
+			return "[" + strings.Join(values, ",") + "]"
+		}
+	case TimeType:
+		return field.timeValue().Format(time.RFC3339Nano)
+	}
+
+	return quoteIfNeeded(fmt.Sprint(fieldToValue(field)))
+}
+
+// formatNestedValue formats an object or group within an array as a list of key value pairs within braces.
+func formatNestedValue(field Field) string {
+	var nested []Field
+
+	if field.Type == ObjectType {
+		object, ok := field.Interface.(ObjectMarshaler)
+		if !ok {
+			return formatFieldValue(field)
+		}
+
+		nested = object.MarshalLogObject()
+	} else {
+		nested = field.Interface.([]Field) //nolint: forcetypeassert // We know the type.
+	}
+
+	kvs := flattenFields("", nested)
+	pairs := make([]string, len(kvs))
+
+	for i, kv := range kvs {
+		pairs[i] = kv.key + "=" + kv.value
+	}
+
+	return "{" + strings.Join(pairs, " ") + "}"
+}
+
+func quoteIfNeeded(s string) string {
+	if s == "" {
+		return `""`
+	}
+
+	for _, r := range s {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || r == '"' || r == '=' {
+			return strconv.Quote(s)
+		}
+	}
+
+	return s
+}
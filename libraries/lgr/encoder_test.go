@@ -0,0 +1,72 @@
+package lgr //nolint: testpackage // Tests the unexported encoders.
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files with the output of the tests, e.g. go test -run TestLogfmt -update.
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// assertGolden asserts that got matches the contents of testdata/name, rewriting the file when -update is set.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o600))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+// encoderObject implements ObjectMarshaler for testing the encoding of object fields.
+type encoderObject struct {
+	name string
+}
+
+func (o encoderObject) MarshalLogObject() []Field {
+	return []Field{Str("name", o.name), Integer("id", 1)}
+}
+
+// everyFieldType returns a field of each FieldType.
+func everyFieldType() []Field {
+	return []Field{
+		Bool("bool", true),
+		ByteStr("byteStr", []byte("some bytes")),
+		Duration("duration", 1500*time.Millisecond),
+		Err(errors.New("some error")),
+		Float("float32", float32(1.5)),
+		Float("float64", 2.25),
+		Integer("int", -1),
+		Integer("int8", int8(-8)),
+		Integer("int16", int16(-16)),
+		Integer("int32", int32(-32)),
+		Integer("int64", int64(-64)),
+		Integer("uint", uint(1)),
+		Integer("uint8", uint8(8)),
+		Integer("uint16", uint16(16)),
+		Integer("uint32", uint32(32)),
+		Integer("uint64", uint64(64)),
+		Integer("uintptr", uintptr(0xff)),
+		Str("string", "some string"),
+		Time("time", time.Date(2022, time.March, 4, 12, 30, 0, 0, time.UTC)),
+		Strs("array", []string{"a", "b"}),
+		Durations("durations", []time.Duration{time.Second, time.Minute}),
+		Object("object", encoderObject{name: "some name"}),
+		Group("group", Str("key", "value"), Group("nested", Bool("ok", false))),
+		Secret("secret", "hunter2"),
+		Str("id", "abc-123"),
+		Str("with space", "quoted \"value\""),
+	}
+}
@@ -0,0 +1,169 @@
+package lgr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/grpc/status"
+)
+
+// maxStackDepth is the maximum number of frames that will be captured for a stack trace.
+const maxStackDepth = 32
+
+// stackError is an error that carries the stack trace of where it was created.
+type stackError struct {
+	err   error
+	stack []uintptr
+}
+
+// Errorf formats according to a format specifier and returns the resulting error with the stack
+// trace of the caller attached. Verbs such as %w are handled in the same way as fmt.Errorf so the
+// returned error can be inspected with errors.Is and errors.As. The stack trace is added to the log
+// context when the error is logged by a Logger created with WithErrorDetails.
+func Errorf(format string, args ...any) error {
+	const skip = 3 // Skip runtime.Callers, callers and Errorf.
+
+	return &stackError{
+		err:   fmt.Errorf(format, args...), //nolint: goerr113 // Wrapping is the responsibility of the caller.
+		stack: callers(skip, maxStackDepth),
+	}
+}
+
+// Error returns the message of the wrapped error.
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error that was created by fmt.Errorf.
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace returns each frame of the stack trace formatted as "function file:line".
+func (e *stackError) StackTrace() []string {
+	return formatFrames(e.stack)
+}
+
+// WithErrorDetails enables the enrichment of error fields. Rather than just the error message, each
+// error field is written as a group containing the message, the concrete type of the error, the chain
+// of wrapped errors, the stack trace for errors created with Errorf and the code and details of any
+// gRPC status carried by the error.
+func WithErrorDetails() Option {
+	return func(l *Logger) {
+		l.errorDetails = true
+	}
+}
+
+// withErrorDetails replaces each error field with a group field describing the error. The given
+// slice is not modified as it may be owned by the caller.
+func withErrorDetails(fields []Field) []Field {
+	var detailed []Field
+
+	for i, field := range fields {
+		err, ok := field.Interface.(error)
+		if field.Type != ErrorType || !ok {
+			continue
+		}
+
+		if detailed == nil {
+			detailed = make([]Field, len(fields))
+			copy(detailed, fields)
+		}
+
+		detailed[i] = errorDetails(field.Key, err)
+	}
+
+	if detailed == nil {
+		return fields
+	}
+
+	return detailed
+}
+
+// errorDetails creates a group field describing the given error.
+func errorDetails(key string, err error) Field {
+	details := []Field{
+		Str("message", err.Error()),
+		Str("type", errorType(err)),
+	}
+
+	if chain := errorChain(err); len(chain) > 0 {
+		details = append(details, Array("chain", fieldArray(chain)))
+	}
+
+	var stackErr *stackError
+	if errors.As(err, &stackErr) {
+		details = append(details, Strs("stack", stackErr.StackTrace()))
+	}
+
+	if grpcStatus, ok := status.FromError(err); ok {
+		statusDetails := grpcStatus.Details()
+		formatted := make([]string, len(statusDetails))
+
+		for i, detail := range statusDetails {
+			formatted[i] = fmt.Sprintf("%v", detail)
+		}
+
+		details = append(details, Str("grpcCode", grpcStatus.Code().String()), Strs("grpcDetails", formatted))
+	}
+
+	return Group(key, details...)
+}
+
+// errorChain returns a group field for each error wrapped by err, found by walking the errors
+// returned from Unwrap depth first. An error created by Errorf and the error created by fmt.Errorf
+// that it wraps share a message and type so they are listed once.
+func errorChain(err error) []Field {
+	if stackErr, ok := err.(*stackError); ok { //nolint: errorlint // We only want to skip this exact error.
+		err = stackErr.err
+	}
+
+	var chain []Field
+
+	for _, wrapped := range unwrap(err) {
+		chain = append(chain, Group("", Str("message", wrapped.Error()), Str("type", errorType(wrapped))))
+		chain = append(chain, errorChain(wrapped)...)
+	}
+
+	return chain
+}
+
+// unwrap returns the errors directly wrapped by err, supporting both errors.Unwrap and errors.Join.
+func unwrap(err error) []error {
+	switch e := err.(type) { //nolint: errorlint // We are only interested in the errors directly wrapped by err.
+	case interface{ Unwrap() error }:
+		if wrapped := e.Unwrap(); wrapped != nil {
+			return []error{wrapped}
+		}
+	case interface{ Unwrap() []error }:
+		wrapped := make([]error, 0, len(e.Unwrap()))
+
+		for _, w := range e.Unwrap() {
+			if w != nil {
+				wrapped = append(wrapped, w)
+			}
+		}
+
+		return wrapped
+	}
+
+	return nil
+}
+
+// errorType returns the name of the concrete type of err. Errors created with Errorf report the
+// type of the error that they wrap.
+func errorType(err error) string {
+	if stackErr, ok := err.(*stackError); ok { //nolint: errorlint // We only want to skip this exact error.
+		err = stackErr.err
+	}
+
+	return reflect.TypeOf(err).String()
+}
+
+// fieldArray implements ArrayMarshaler for a slice of fields.
+type fieldArray []Field
+
+func (a fieldArray) MarshalLogArray() []Field {
+	return a
+}
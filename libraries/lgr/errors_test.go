@@ -0,0 +1,216 @@
+package lgr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+func TestErrorf(t *testing.T) {
+	t.Parallel()
+
+	err := lgr.Errorf("reading config: %w", io.EOF)
+
+	assert.EqualError(t, err, "reading config: EOF")
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestWithErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	t.Run("does not enrich errors by default", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		err := fmt.Errorf("reading config: %w", io.EOF)
+
+		logger.Error("my error message", lgr.Err(err))
+
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.ErrorLevel, "my error message", lgr.Err(err))
+	})
+
+	t.Run("adds the type of the error", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		logger := lgr.FromAdapter(adapter, lgr.WithErrorDetails())
+
+		logger.Error("my error message", lgr.Err(io.EOF), lgr.Str("strKey", "some string"))
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.ErrorLevel,
+			"my error message",
+			lgr.Group("error", lgr.Str("message", "EOF"), lgr.Str("type", "*errors.errorString")),
+			lgr.Str("strKey", "some string"),
+		)
+	})
+
+	t.Run("adds the chain of wrapped errors", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		logger := lgr.FromAdapter(adapter, lgr.WithErrorDetails())
+		pathErr := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+		err := fmt.Errorf("loading: %w", errors.Join(pathErr, io.EOF))
+
+		logger.Error("my error message", lgr.NamedErr("loadErr", err))
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.ErrorLevel,
+			"my error message",
+			lgr.Group(
+				"loadErr",
+				lgr.Str("message", "loading: open config.yaml: file does not exist\nEOF"),
+				lgr.Str("type", "*fmt.wrapError"),
+				lgr.Array("chain", chain{
+					lgr.Group("", lgr.Str("message", "open config.yaml: file does not exist\nEOF"), lgr.Str("type", "*errors.joinError")),
+					lgr.Group("", lgr.Str("message", "open config.yaml: file does not exist"), lgr.Str("type", "*fs.PathError")),
+					lgr.Group("", lgr.Str("message", "file does not exist"), lgr.Str("type", "*errors.errorString")),
+					lgr.Group("", lgr.Str("message", "EOF"), lgr.Str("type", "*errors.errorString")),
+				}),
+			),
+		)
+	})
+
+	t.Run("adds the stack trace of errors created with Errorf", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		logger := lgr.FromAdapter(adapter, lgr.WithErrorDetails())
+
+		logger.Error("my error message", lgr.Err(fmt.Errorf("handling: %w", lgr.Errorf("reading: %w", io.EOF))))
+
+		field, _ := entries.Idx(0).Field("error")
+		group, ok := field.Interface.([]lgr.Field)
+		require.True(t, ok, "error field is not a group")
+		require.Len(t, group, 4)
+
+		assert.Equal(t, lgr.Str("message", "handling: reading: EOF"), group[0])
+		assert.Equal(t, lgr.Str("type", "*fmt.wrapError"), group[1])
+		lgrtest.AssertFullEntry(
+			t,
+			lgrtest.Entry{Level: lgr.ErrorLevel, Fields: []lgr.Field{group[2]}},
+			lgr.ErrorLevel,
+			"",
+			lgr.Array("chain", chain{
+				lgr.Group("", lgr.Str("message", "reading: EOF"), lgr.Str("type", "*fmt.wrapError")),
+				lgr.Group("", lgr.Str("message", "EOF"), lgr.Str("type", "*errors.errorString")),
+			}),
+		)
+
+		assert.Equal(t, "stack", group[3].Key)
+
+		stack, ok := group[3].Interface.(lgr.ArrayMarshaler)
+		require.True(t, ok, "stack field is not an array")
+
+		frames := stack.MarshalLogArray()
+		require.NotEmpty(t, frames)
+
+		require.Equal(t, lgr.StringType, frames[0].Type, "stack frame is not a string")
+
+		frame := frames[0].String
+		assert.True(t, strings.HasPrefix(frame, "github.com/nickbryan/collectable/libraries/lgr_test.TestWithErrorDetails"), frame)
+		assert.Contains(t, frame, "errors_test.go:")
+	})
+
+	t.Run("does not repeat an error created with Errorf in its chain", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		logger := lgr.FromAdapter(adapter, lgr.WithErrorDetails())
+
+		logger.Error("my error message", lgr.Err(lgr.Errorf("outer: %w", lgr.Errorf("inner: %w", io.EOF))))
+
+		field, _ := entries.Idx(0).Field("error")
+		group, ok := field.Interface.([]lgr.Field)
+		require.True(t, ok, "error field is not a group")
+		require.Len(t, group, 4)
+
+		assert.Equal(t, lgr.Str("message", "outer: inner: EOF"), group[0])
+		lgrtest.AssertFullEntry(
+			t,
+			lgrtest.Entry{Level: lgr.ErrorLevel, Fields: []lgr.Field{group[2]}},
+			lgr.ErrorLevel,
+			"",
+			lgr.Array("chain", chain{
+				lgr.Group("", lgr.Str("message", "inner: EOF"), lgr.Str("type", "*fmt.wrapError")),
+				lgr.Group("", lgr.Str("message", "EOF"), lgr.Str("type", "*errors.errorString")),
+			}),
+		)
+	})
+
+	t.Run("writes nil errors without details", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		logger := lgr.FromAdapter(adapter, lgr.WithErrorDetails())
+
+		logger.Error("my error message", lgr.Err(nil))
+
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.ErrorLevel, "my error message", lgr.Err(nil))
+	})
+
+	t.Run("adds the gRPC status", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		logger := lgr.FromAdapter(adapter, lgr.WithErrorDetails())
+
+		logger.Error("my error message", lgr.Err(status.Error(codes.NotFound, "identity not found")))
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.ErrorLevel,
+			"my error message",
+			lgr.Group(
+				"error",
+				lgr.Str("message", "rpc error: code = NotFound desc = identity not found"),
+				lgr.Str("type", "*status.Error"),
+				lgr.Str("grpcCode", "NotFound"),
+				lgr.Strs("grpcDetails", []string{}),
+			),
+		)
+	})
+
+	t.Run("applies to bound fields without modifying them", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		logger := lgr.FromAdapter(adapter).With(lgr.Err(io.EOF))
+
+		lgr.FromAdapter(adapter, lgr.WithErrorDetails()).With(lgr.Err(io.EOF)).Info("detailed message")
+		logger.Info("plain message")
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.InfoLevel,
+			"detailed message",
+			lgr.Group("error", lgr.Str("message", "EOF"), lgr.Str("type", "*errors.errorString")),
+		)
+		lgrtest.AssertFullEntry(t, entries.Idx(1), lgr.InfoLevel, "plain message", lgr.Err(io.EOF))
+	})
+}
+
+// chain implements lgr.ArrayMarshaler for asserting the chain of wrapped errors.
+type chain []lgr.Field
+
+func (c chain) MarshalLogArray() []lgr.Field {
+	return c
+}
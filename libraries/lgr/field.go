@@ -1,6 +1,7 @@
 package lgr
 
 import (
+	"math"
 	"time"
 
 	"golang.org/x/exp/constraints"
@@ -51,80 +52,160 @@ const (
 	StringType
 	// TimeType indicates that the field carries a tiem.Time object.
 	TimeType
+	// ObjectType indicates that the field carries an ObjectMarshaler.
+	ObjectType
+	// ArrayType indicates that the field carries an ArrayMarshaler.
+	ArrayType
+	// GroupType indicates that the field carries a slice of Field that should be nested under the key.
+	GroupType
+	// SecretType indicates that the field carries a sensitive string that must never be written in clear text.
+	SecretType
 )
 
-// Field represents a key value pair that should be added to the log context. The
-// Tyoe is used by the Adapter to write logs in a type safe way.
+// Field represents a key value pair that should be added to the log context. The Type is used by the
+// Adapter to write logs in a type safe way and determines which member of the union carries the value.
+// Numeric values, booleans, durations and times are carried in Integer and strings are carried in String
+// so that constructing a Field for the common types does not allocate. All other values are carried in
+// Interface. Fields should be created with the constructors rather than by hand and the value should
+// be read with Value.
 type Field struct {
-	Type  FieldType
-	Key   string
-	Value any
+	Type      FieldType
+	Key       string
+	Integer   int64
+	String    string
+	Interface any
+}
+
+// Value returns the value carried by the Field as its original Go type, such as an int8 for an Int8Type
+// Field. It allocates for most types so adapters should prefer to read the member of the union that
+// carries the value for the Type of the Field.
+func (f Field) Value() any { //nolint: cyclop // Easier to read whole type switch.
+	switch f.Type {
+	case BoolType:
+		return f.Integer == 1
+	case DurationType:
+		return time.Duration(f.Integer)
+	case Float32Type:
+		return math.Float32frombits(uint32(f.Integer))
+	case Float64Type:
+		return math.Float64frombits(uint64(f.Integer))
+	case IntType:
+		return int(f.Integer)
+	case Int8Type:
+		return int8(f.Integer)
+	case Int16Type:
+		return int16(f.Integer)
+	case Int32Type:
+		return int32(f.Integer)
+	case Int64Type:
+		return f.Integer
+	case UintType:
+		return uint(f.Integer)
+	case Uint8Type:
+		return uint8(f.Integer)
+	case Uint16Type:
+		return uint16(f.Integer)
+	case Uint32Type:
+		return uint32(f.Integer)
+	case Uint64Type:
+		return uint64(f.Integer)
+	case UintptrType:
+		return uintptr(f.Integer)
+	case StringType:
+		return f.String
+	case TimeType:
+		return f.timeValue()
+	case UnkownType, ByteStringType, ErrorType, ObjectType, ArrayType, GroupType, SecretType:
+		fallthrough
+	default:
+		return f.Interface
+	}
+}
+
+// timeValue returns the time carried by a TimeType Field. Times that can be represented as nanoseconds
+// since the Unix epoch are carried in Integer with their location in Interface so that they do not
+// allocate. All other times are carried in Interface.
+func (f Field) timeValue() time.Time {
+	switch value := f.Interface.(type) {
+	case time.Time:
+		return value
+	case *time.Location:
+		return time.Unix(0, f.Integer).In(value)
+	default:
+		return time.Unix(0, f.Integer)
+	}
+}
+
+// ObjectMarshaler allows user defined types to control how they are added to the log context. The
+// returned fields are nested under the key of the Field so that the type is written as an object.
+type ObjectMarshaler interface {
+	// MarshalLogObject returns the fields that make up the object.
+	MarshalLogObject() []Field
+}
+
+// ArrayMarshaler allows user defined types to control how they are added to the log context. The
+// returned fields are written as the elements of an array under the key of the Field.
+type ArrayMarshaler interface {
+	// MarshalLogArray returns the elements of the array. The key of each Field is ignored.
+	MarshalLogArray() []Field
+}
+
+// Array constructs a Field that carries an ArrayMarshaler with the given key.
+func Array(key string, value ArrayMarshaler) Field {
+	return Field{Type: ArrayType, Key: key, Integer: 0, String: "", Interface: value}
 }
 
 // Bool constructs a Field that carries a boolean value with the given key.
 func Bool(key string, value bool) Field {
-	return Field{
-		Type:  BoolType,
-		Key:   key,
-		Value: value,
+	var integer int64
+	if value {
+		integer = 1
 	}
+
+	return Field{Type: BoolType, Key: key, Integer: integer, String: "", Interface: nil}
 }
 
 // ByteStr constructs a Field that carries a slice of UTF-8 encoded bytes with the given key.
 func ByteStr(key string, value []byte) Field {
-	return Field{
-		Type:  ByteStringType,
-		Key:   key,
-		Value: value,
-	}
+	return Field{Type: ByteStringType, Key: key, Integer: 0, String: "", Interface: value}
 }
 
 // Duration constructs a Field that carries a time.Duration value with the given key.
 func Duration(key string, value time.Duration) Field {
-	return Field{
-		Type:  DurationType,
-		Key:   key,
-		Value: value,
-	}
+	return Field{Type: DurationType, Key: key, Integer: int64(value), String: "", Interface: nil}
+}
+
+// Durations constructs a Field that carries a slice of time.Duration values as an array with the given key.
+func Durations(key string, values []time.Duration) Field {
+	return Array(key, durations(values))
 }
 
 // Err constructs a Field that carries an error value with the key "error".
 func Err(err error) Field {
-	return Field{
-		Type:  ErrorType,
-		Key:   "error",
-		Value: err,
-	}
+	return NamedErr("error", err)
 }
 
 // NamedErr constructs a Field that carries an error with the given key.
 func NamedErr(key string, err error) Field {
-	return Field{
-		Type:  ErrorType,
-		Key:   key,
-		Value: err,
-	}
+	return Field{Type: ErrorType, Key: key, Integer: 0, String: "", Interface: err}
 }
 
 // Float provides generic construction of a Field for float32 and float64 values.
 func Float[T constraints.Float](key string, value T) Field {
-	var typ FieldType
-
-	switch any(value).(type) {
-	case float32:
-		typ = Float32Type
-	case float64:
-		typ = Float64Type
+	if _, ok := any(value).(float32); ok {
+		return Field{Type: Float32Type, Key: key, Integer: int64(math.Float32bits(float32(value))), String: "", Interface: nil}
 	}
 
-	return Field{
-		Type:  typ,
-		Key:   key,
-		Value: value,
-	}
+	return Field{Type: Float64Type, Key: key, Integer: int64(math.Float64bits(float64(value))), String: "", Interface: nil}
+}
+
+// Group constructs a Field that nests the given fields under the given key.
+func Group(key string, fields ...Field) Field {
+	return Field{Type: GroupType, Key: key, Integer: 0, String: "", Interface: fields}
 }
 
-// Integer provides generic construction of a Field for all int and uint values.
+// Integer provides generic construction of a Field for all int and uint values. Unsigned values are
+// carried with the same bits as a signed value and converted back according to the Type of the Field.
 func Integer[T constraints.Integer](key string, value T) Field {
 	var typ FieldType
 
@@ -153,27 +234,134 @@ func Integer[T constraints.Integer](key string, value T) Field {
 		typ = UintptrType
 	}
 
-	return Field{
-		Type:  typ,
-		Key:   key,
-		Value: value,
-	}
+	return Field{Type: typ, Key: key, Integer: int64(value), String: "", Interface: nil}
+}
+
+// Ints provides generic construction of a Field for slices of all int and uint values. The values are
+// carried as an array with the given key.
+func Ints[T constraints.Integer](key string, values []T) Field {
+	return Array(key, ints[T](values))
+}
+
+// Object constructs a Field that carries an ObjectMarshaler with the given key.
+func Object(key string, value ObjectMarshaler) Field {
+	return Field{Type: ObjectType, Key: key, Integer: 0, String: "", Interface: value}
+}
+
+// Secret constructs a Field that carries a sensitive string value, such as a password or token, with the
+// given key. Adapters write the value as "[REDACTED]" and the value is also redacted when it is formatted
+// with the fmt package or encoded as JSON so that it is never written in clear text.
+func Secret(key, value string) Field {
+	return Field{Type: SecretType, Key: key, Integer: 0, String: "", Interface: secret(value)}
 }
 
 // Str constructs a Field that carries a string value with the given key.
 func Str(key, value string) Field {
-	return Field{
-		Type:  StringType,
-		Key:   key,
-		Value: value,
-	}
+	return Field{Type: StringType, Key: key, Integer: 0, String: value, Interface: nil}
 }
 
-// Time constructs a Field that carries a time.Time object with the given key.
+// Strs constructs a Field that carries a slice of string values as an array with the given key.
+func Strs(key string, values []string) Field {
+	return Array(key, strs(values))
+}
+
+// Time constructs a Field that carries a time.Time object with the given key. The monotonic clock
+// reading of the time is not kept.
 func Time(key string, value time.Time) Field {
-	return Field{
-		Type:  TimeType,
-		Key:   key,
-		Value: value,
+	if value.Before(minUnixNanoTime) || value.After(maxUnixNanoTime) {
+		return Field{Type: TimeType, Key: key, Integer: 0, String: "", Interface: value}
 	}
+
+	return Field{Type: TimeType, Key: key, Integer: value.UnixNano(), String: "", Interface: value.Location()}
+}
+
+// The range of times that can be represented as nanoseconds since the Unix epoch by an int64.
+var (
+	minUnixNanoTime = time.Unix(0, math.MinInt64)
+	maxUnixNanoTime = time.Unix(0, math.MaxInt64)
+)
+
+// fieldsToValues converts the fields to their underlying values so that adapters without native
+// support for a structure can fall back to encoding it via reflection. Objects and groups are
+// converted to map[string]any, arrays to []any and errors to their message.
+func fieldsToValues(fields []Field) []any {
+	values := make([]any, len(fields))
+	for i, field := range fields {
+		values[i] = fieldToValue(field)
+	}
+
+	return values
+}
+
+func fieldsToMap(fields []Field) map[string]any {
+	values := make(map[string]any, len(fields))
+	for _, field := range fields {
+		values[field.Key] = fieldToValue(field)
+	}
+
+	return values
+}
+
+func fieldToValue(field Field) any {
+	switch field.Type { //nolint: exhaustive // All other types are returned as they are.
+	case ObjectType:
+		if object, ok := field.Interface.(ObjectMarshaler); ok {
+			return fieldsToMap(object.MarshalLogObject())
+		}
+
+		return nil
+	case ArrayType:
+		if array, ok := field.Interface.(ArrayMarshaler); ok {
+			return fieldsToValues(array.MarshalLogArray())
+		}
+
+		return nil
+	case GroupType:
+		return fieldsToMap(field.Interface.([]Field)) //nolint: forcetypeassert // We know the type.
+	case ErrorType:
+		if err, ok := field.Interface.(error); ok {
+			return err.Error()
+		}
+
+		return nil
+	case ByteStringType:
+		return string(field.Interface.([]byte)) //nolint: forcetypeassert // We know the type.
+	case SecretType:
+		return redactedValue
+	default:
+		return field.Value()
+	}
+}
+
+type durations []time.Duration
+
+func (d durations) MarshalLogArray() []Field {
+	fields := make([]Field, len(d))
+	for i, value := range d {
+		fields[i] = Duration("", value)
+	}
+
+	return fields
+}
+
+type ints[T constraints.Integer] []T
+
+func (n ints[T]) MarshalLogArray() []Field {
+	fields := make([]Field, len(n))
+	for i, value := range n {
+		fields[i] = Integer("", value)
+	}
+
+	return fields
+}
+
+type strs []string
+
+func (s strs) MarshalLogArray() []Field {
+	fields := make([]Field, len(s))
+	for i, value := range s {
+		fields[i] = Str("", value)
+	}
+
+	return fields
 }
@@ -2,6 +2,7 @@ package lgr_test
 
 import (
 	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -10,174 +11,249 @@ import (
 	"github.com/nickbryan/collectable/libraries/lgr"
 )
 
+// testObject implements lgr.ObjectMarshaler for testing object fields.
+type testObject struct {
+	name string
+}
+
+func (o testObject) MarshalLogObject() []lgr.Field {
+	return []lgr.Field{lgr.Str("name", o.name)}
+}
+
+// testArray implements lgr.ArrayMarshaler for testing array fields.
+type testArray []string
+
+func (a testArray) MarshalLogArray() []lgr.Field {
+	fields := make([]lgr.Field, len(a))
+	for i, value := range a {
+		fields[i] = lgr.Str("", value)
+	}
+
+	return fields
+}
+
 func TestField(t *testing.T) {
 	t.Parallel()
 
-	// Used to assert time for time fields.
-	now := time.Now()
+	// Used to assert time for time fields. The monotonic clock reading is not kept by the Field.
+	now := time.Now().Round(0)
+	ancient := time.Date(1066, time.October, 14, 9, 0, 0, 0, time.UTC)
 
 	testCases := map[string]struct {
-		got, want lgr.Field
+		got       lgr.Field
+		wantType  lgr.FieldType
+		wantKey   string
+		wantValue any
 	}{
+		"array": {
+			got:       lgr.Array("arrayKey", testArray{"a", "b"}),
+			wantType:  lgr.ArrayType,
+			wantKey:   "arrayKey",
+			wantValue: testArray{"a", "b"},
+		},
 		"bool": {
-			got: lgr.Bool("boolKey", true),
-			want: lgr.Field{
-				Type:  lgr.BoolType,
-				Key:   "boolKey",
-				Value: true,
-			},
+			got:       lgr.Bool("boolKey", true),
+			wantType:  lgr.BoolType,
+			wantKey:   "boolKey",
+			wantValue: true,
 		},
 		"byte string": {
-			got: lgr.ByteStr("byteStrKey", []byte(`some byte string`)),
-			want: lgr.Field{
-				Type:  lgr.ByteStringType,
-				Key:   "byteStrKey",
-				Value: []byte(`some byte string`),
-			},
+			got:       lgr.ByteStr("byteStrKey", []byte(`some byte string`)),
+			wantType:  lgr.ByteStringType,
+			wantKey:   "byteStrKey",
+			wantValue: []byte(`some byte string`),
 		},
 		"duration": {
-			got: lgr.Duration("durationKey", time.Hour),
-			want: lgr.Field{
-				Type:  lgr.DurationType,
-				Key:   "durationKey",
-				Value: time.Hour,
-			},
+			got:       lgr.Duration("durationKey", time.Hour),
+			wantType:  lgr.DurationType,
+			wantKey:   "durationKey",
+			wantValue: time.Hour,
 		},
 		"error": {
-			got: lgr.Err(errors.New("my error string")),
-			want: lgr.Field{
-				Type:  lgr.ErrorType,
-				Key:   "error",
-				Value: errors.New("my error string"),
-			},
+			got:       lgr.Err(errors.New("my error string")),
+			wantType:  lgr.ErrorType,
+			wantKey:   "error",
+			wantValue: errors.New("my error string"),
 		},
 		"named error": {
-			got: lgr.NamedErr("namedErrKey", errors.New("my named error string")),
-			want: lgr.Field{
-				Type:  lgr.ErrorType,
-				Key:   "namedErrKey",
-				Value: errors.New("my named error string"),
-			},
+			got:       lgr.NamedErr("namedErrKey", errors.New("my named error string")),
+			wantType:  lgr.ErrorType,
+			wantKey:   "namedErrKey",
+			wantValue: errors.New("my named error string"),
 		},
 		"float->float32": {
-			got: lgr.Float("floatToFloat32Key", float32(4.20)),
-			want: lgr.Field{
-				Type:  lgr.Float32Type,
-				Key:   "floatToFloat32Key",
-				Value: float32(4.20),
-			},
+			got:       lgr.Float("floatToFloat32Key", float32(4.20)),
+			wantType:  lgr.Float32Type,
+			wantKey:   "floatToFloat32Key",
+			wantValue: float32(4.20),
 		},
 		"float->float64": {
-			got: lgr.Float("floatToFloat64Key", float64(0.24)),
-			want: lgr.Field{
-				Type:  lgr.Float64Type,
-				Key:   "floatToFloat64Key",
-				Value: float64(0.24),
-			},
+			got:       lgr.Float("floatToFloat64Key", float64(0.24)),
+			wantType:  lgr.Float64Type,
+			wantKey:   "floatToFloat64Key",
+			wantValue: float64(0.24),
+		},
+		"group": {
+			got:       lgr.Group("groupKey", lgr.Str("stringKey", "some string value"), lgr.Bool("boolKey", true)),
+			wantType:  lgr.GroupType,
+			wantKey:   "groupKey",
+			wantValue: []lgr.Field{lgr.Str("stringKey", "some string value"), lgr.Bool("boolKey", true)},
 		},
 		"integer->int": {
-			got: lgr.Integer("integerToIntKey", int(42)),
-			want: lgr.Field{
-				Type:  lgr.IntType,
-				Key:   "integerToIntKey",
-				Value: int(42),
-			},
+			got:       lgr.Integer("integerToIntKey", int(42)),
+			wantType:  lgr.IntType,
+			wantKey:   "integerToIntKey",
+			wantValue: int(42),
 		},
 		"integer->int8": {
-			got: lgr.Integer("integerToInt8Key", int8(42)),
-			want: lgr.Field{
-				Type:  lgr.Int8Type,
-				Key:   "integerToInt8Key",
-				Value: int8(42),
-			},
+			got:       lgr.Integer("integerToInt8Key", int8(42)),
+			wantType:  lgr.Int8Type,
+			wantKey:   "integerToInt8Key",
+			wantValue: int8(42),
 		},
 		"integer->int16": {
-			got: lgr.Integer("integerToInt16Key", int16(42)),
-			want: lgr.Field{
-				Type:  lgr.Int16Type,
-				Key:   "integerToInt16Key",
-				Value: int16(42),
-			},
+			got:       lgr.Integer("integerToInt16Key", int16(42)),
+			wantType:  lgr.Int16Type,
+			wantKey:   "integerToInt16Key",
+			wantValue: int16(42),
 		},
 		"integer->int32": {
-			got: lgr.Integer("integerToInt32Key", int32(42)),
-			want: lgr.Field{
-				Type:  lgr.Int32Type,
-				Key:   "integerToInt32Key",
-				Value: int32(42),
-			},
+			got:       lgr.Integer("integerToInt32Key", int32(42)),
+			wantType:  lgr.Int32Type,
+			wantKey:   "integerToInt32Key",
+			wantValue: int32(42),
 		},
 		"integer->int64": {
-			got: lgr.Integer("integerToInt64Key", int64(42)),
-			want: lgr.Field{
-				Type:  lgr.Int64Type,
-				Key:   "integerToInt64Key",
-				Value: int64(42),
-			},
+			got:       lgr.Integer("integerToInt64Key", int64(42)),
+			wantType:  lgr.Int64Type,
+			wantKey:   "integerToInt64Key",
+			wantValue: int64(42),
 		},
 		"integer->uint": {
-			got: lgr.Integer("integerToUintKey", uint(42)),
-			want: lgr.Field{
-				Type:  lgr.UintType,
-				Key:   "integerToUintKey",
-				Value: uint(42),
-			},
+			got:       lgr.Integer("integerToUintKey", uint(42)),
+			wantType:  lgr.UintType,
+			wantKey:   "integerToUintKey",
+			wantValue: uint(42),
 		},
 		"integer->uint8": {
-			got: lgr.Integer("integerToUint8Key", uint8(42)),
-			want: lgr.Field{
-				Type:  lgr.Uint8Type,
-				Key:   "integerToUint8Key",
-				Value: uint8(42),
-			},
+			got:       lgr.Integer("integerToUint8Key", uint8(42)),
+			wantType:  lgr.Uint8Type,
+			wantKey:   "integerToUint8Key",
+			wantValue: uint8(42),
 		},
 		"integer->uint16": {
-			got: lgr.Integer("integerToUint16Key", uint16(42)),
-			want: lgr.Field{
-				Type:  lgr.Uint16Type,
-				Key:   "integerToUint16Key",
-				Value: uint16(42),
-			},
+			got:       lgr.Integer("integerToUint16Key", uint16(42)),
+			wantType:  lgr.Uint16Type,
+			wantKey:   "integerToUint16Key",
+			wantValue: uint16(42),
 		},
 		"integer->uint32": {
-			got: lgr.Integer("integerToUint32Key", uint32(42)),
-			want: lgr.Field{
-				Type:  lgr.Uint32Type,
-				Key:   "integerToUint32Key",
-				Value: uint32(42),
-			},
+			got:       lgr.Integer("integerToUint32Key", uint32(42)),
+			wantType:  lgr.Uint32Type,
+			wantKey:   "integerToUint32Key",
+			wantValue: uint32(42),
 		},
 		"integer->uint64": {
-			got: lgr.Integer("integerToUint64Key", uint64(42)),
-			want: lgr.Field{
-				Type:  lgr.Uint64Type,
-				Key:   "integerToUint64Key",
-				Value: uint64(42),
-			},
+			got:       lgr.Integer("integerToUint64Key", uint64(42)),
+			wantType:  lgr.Uint64Type,
+			wantKey:   "integerToUint64Key",
+			wantValue: uint64(42),
 		},
 		"integer->uintptr": {
-			got: lgr.Integer("integerToUintptrKey", uintptr(42)),
-			want: lgr.Field{
-				Type:  lgr.UintptrType,
-				Key:   "integerToUintptrKey",
-				Value: uintptr(42),
-			},
+			got:       lgr.Integer("integerToUintptrKey", uintptr(42)),
+			wantType:  lgr.UintptrType,
+			wantKey:   "integerToUintptrKey",
+			wantValue: uintptr(42),
+		},
+		"object": {
+			got:       lgr.Object("objectKey", testObject{name: "some name"}),
+			wantType:  lgr.ObjectType,
+			wantKey:   "objectKey",
+			wantValue: testObject{name: "some name"},
 		},
 		"string": {
-			got: lgr.Str("stringKey", "some string value"),
-			want: lgr.Field{
-				Type:  lgr.StringType,
-				Key:   "stringKey",
-				Value: "some string value",
-			},
+			got:       lgr.Str("stringKey", "some string value"),
+			wantType:  lgr.StringType,
+			wantKey:   "stringKey",
+			wantValue: "some string value",
+		},
+		"negative int": {
+			got:       lgr.Integer("intKey", -42),
+			wantType:  lgr.IntType,
+			wantKey:   "intKey",
+			wantValue: -42,
+		},
+		"max uint64": {
+			got:       lgr.Integer("uint64Key", uint64(math.MaxUint64)),
+			wantType:  lgr.Uint64Type,
+			wantKey:   "uint64Key",
+			wantValue: uint64(math.MaxUint64),
+		},
+		"false": {
+			got:       lgr.Bool("boolKey", false),
+			wantType:  lgr.BoolType,
+			wantKey:   "boolKey",
+			wantValue: false,
+		},
+		"time outside of the unix nano range": {
+			got:       lgr.Time("timeKey", ancient),
+			wantType:  lgr.TimeType,
+			wantKey:   "timeKey",
+			wantValue: ancient,
 		},
 		"time": {
-			got: lgr.Time("timeKey", now),
-			want: lgr.Field{
-				Type:  lgr.TimeType,
-				Key:   "timeKey",
-				Value: now,
-			},
+			got:       lgr.Time("timeKey", now),
+			wantType:  lgr.TimeType,
+			wantKey:   "timeKey",
+			wantValue: now,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.wantType, tc.got.Type)
+			assert.Equal(t, tc.wantKey, tc.got.Key)
+			assert.Equal(t, tc.wantValue, tc.got.Value())
+		})
+	}
+}
+
+func TestSliceFields(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		got          lgr.Field
+		wantKey      string
+		wantElements []lgr.Field
+	}{
+		"durations": {
+			got:          lgr.Durations("durationsKey", []time.Duration{time.Second, time.Minute}),
+			wantKey:      "durationsKey",
+			wantElements: []lgr.Field{lgr.Duration("", time.Second), lgr.Duration("", time.Minute)},
+		},
+		"ints->int": {
+			got:          lgr.Ints("intsKey", []int{1, 2}),
+			wantKey:      "intsKey",
+			wantElements: []lgr.Field{lgr.Integer("", 1), lgr.Integer("", 2)},
+		},
+		"ints->uint8": {
+			got:          lgr.Ints("uint8sKey", []uint8{1, 2}),
+			wantKey:      "uint8sKey",
+			wantElements: []lgr.Field{lgr.Integer("", uint8(1)), lgr.Integer("", uint8(2))},
+		},
+		"strs": {
+			got:          lgr.Strs("strsKey", []string{"a", "b"}),
+			wantKey:      "strsKey",
+			wantElements: []lgr.Field{lgr.Str("", "a"), lgr.Str("", "b")},
+		},
+		"empty": {
+			got:          lgr.Strs("emptyKey", nil),
+			wantKey:      "emptyKey",
+			wantElements: []lgr.Field{},
 		},
 	}
 
@@ -187,7 +263,13 @@ func TestField(t *testing.T) {
 		t.Run(tn, func(t *testing.T) {
 			t.Parallel()
 
-			assert.Equal(t, tc.want, tc.got)
+			assert.Equal(t, lgr.ArrayType, tc.got.Type)
+			assert.Equal(t, tc.wantKey, tc.got.Key)
+
+			array, ok := tc.got.Interface.(lgr.ArrayMarshaler)
+			if assert.True(t, ok, "value does not implement lgr.ArrayMarshaler") {
+				assert.Equal(t, tc.wantElements, array.MarshalLogArray())
+			}
 		})
 	}
 }
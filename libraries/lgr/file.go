@@ -0,0 +1,481 @@
+package lgr
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// ownerRWOnly is the mode that log files are created with as they may contain sensitive information.
+	ownerRWOnly = 0o600
+	// backupTimeFormat is the format of the timestamp added to the name of rotated files. It does not
+	// contain characters that are invalid in file names.
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	// compressSuffix is appended to the name of rotated files that have been compressed.
+	compressSuffix = ".gz"
+)
+
+// Rotation configures when the file that logs are written to is rotated. When a file is rotated it is
+// renamed with the time of rotation added to its name, e.g. app-2022-03-05T00-00-00.000.log, and a new
+// file is created at the original path. A sequence number is added when a file has already been rotated
+// at the same time, e.g. app-2022-03-05T00-00-00.000-1.log. Rotated files are compressed and removed in
+// the background so that writes are not blocked. The zero value disables rotation.
+type Rotation struct {
+	// MaxSize is the size in bytes that the file can grow to before it is rotated. Zero disables
+	// rotation by size.
+	MaxSize int64
+	// MaxAge is how long logs are written to the file before it is rotated, measured from when the
+	// file was opened. Zero disables rotation by age.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files to keep, deleting the oldest first. Zero keeps all
+	// rotated files.
+	MaxBackups int
+	// Compress enables gzip compression of rotated files.
+	Compress bool
+	// ReopenOnSIGHUP reopens the file when the process receives SIGHUP so that external tools such as
+	// logrotate can move it. Enabling it registers a SIGHUP handler with os/signal, which stops SIGHUP
+	// from terminating the process until the file is closed.
+	ReopenOnSIGHUP bool
+}
+
+// WithRotation enables rotation of the file set by WithOutputPath. Outputs configured with WithOutputs
+// set their own Rotation.
+func WithRotation(rotation Rotation) Option {
+	return func(l *Logger) {
+		l.rotation = rotation
+	}
+}
+
+// logFile is an io.Writer that writes to a file, rotating it according to its Rotation. The file is
+// reopened when the process receives SIGHUP if Rotation.ReopenOnSIGHUP is set.
+type logFile struct {
+	mu       sync.Mutex
+	path     string
+	rotation Rotation
+	now      func() time.Time
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	signals  chan os.Signal
+	done     chan struct{}
+
+	// cleanupMu serialises the compression and removal of backups, which run in the background.
+	cleanupMu  sync.Mutex
+	cleanupErr error
+	cleanups   sync.WaitGroup
+}
+
+// openLogFile opens the file at path for appending, creating it if it does not exist, and starts
+// listening for SIGHUP if Rotation.ReopenOnSIGHUP is set.
+func openLogFile(path string, rotation Rotation) (*logFile, error) {
+	f := &logFile{
+		mu:       sync.Mutex{},
+		path:     path,
+		rotation: rotation,
+		now:      time.Now,
+		file:     nil,
+		size:     0,
+		openedAt: time.Time{},
+		signals:  nil,
+		done:     make(chan struct{}),
+
+		cleanupMu:  sync.Mutex{},
+		cleanupErr: nil,
+		cleanups:   sync.WaitGroup{},
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	if rotation.ReopenOnSIGHUP {
+		f.signals = make(chan os.Signal, 1)
+		signal.Notify(f.signals, syscall.SIGHUP)
+
+		go f.reopenOnSignal()
+	}
+
+	return f, nil
+}
+
+// Write writes p to the file, rotating the file first if writing p would exceed the MaxSize or the
+// file has reached its MaxAge. If the rotation fails p is still written to the current file and the
+// error from the rotation is returned. Rotation is attempted again on the next write.
+func (f *logFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	var rotateErr error
+	if f.shouldRotate(int64(len(p))) {
+		rotateErr = f.rotate()
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	if err != nil {
+		return n, errors.Join(rotateErr, fmt.Errorf("writing log file: %w", err))
+	}
+
+	return n, rotateErr
+}
+
+// Sync commits the contents of the file to disk.
+func (f *logFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	if err := f.file.Sync(); err != nil {
+		return fmt.Errorf("syncing log file: %w", err)
+	}
+
+	return nil
+}
+
+// Reopen closes the file and opens the file at the path again. This allows the file to be moved by
+// an external tool without logs continuing to be written to the moved file.
+func (f *logFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+
+	// The new file is opened before the current file is closed so that logs continue to be written
+	// to the current file if it can not be opened.
+	current := f.file
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if err := current.Close(); err != nil {
+		return fmt.Errorf("closing log file: %w", err)
+	}
+
+	return nil
+}
+
+// Close stops listening for SIGHUP, waits for backups to be compressed and removed and closes the
+// file. Any error from compressing or removing backups is returned. Writes after Close return
+// os.ErrClosed.
+func (f *logFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	if f.signals != nil {
+		signal.Stop(f.signals)
+	}
+
+	close(f.done)
+
+	f.cleanups.Wait()
+
+	var errs []error
+
+	if err := f.file.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("closing log file: %w", err))
+	}
+
+	f.file = nil
+
+	f.cleanupMu.Lock()
+	errs = append(errs, f.cleanupErr)
+	f.cleanupMu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+func (f *logFile) reopenOnSignal() {
+	for {
+		select {
+		case <-f.signals:
+			_ = f.Reopen() // There is nowhere to report the error but the next write will fail.
+		case <-f.done:
+			return
+		}
+	}
+}
+
+// open opens the file at the path. It must be called with the mutex held.
+func (f *logFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, ownerRWOnly)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close() // The error from Stat is more useful to the caller.
+
+		return fmt.Errorf("getting log file info: %w", err)
+	}
+
+	f.file, f.size, f.openedAt = file, info.Size(), f.now()
+
+	return nil
+}
+
+func (f *logFile) shouldRotate(writeSize int64) bool {
+	if f.rotation.MaxSize > 0 && f.size > 0 && f.size+writeSize > f.rotation.MaxSize {
+		return true
+	}
+
+	return f.rotation.MaxAge > 0 && f.now().Sub(f.openedAt) >= f.rotation.MaxAge
+}
+
+// rotate renames the file with a timestamp, opens a new file at the path and then compresses and
+// removes backups in the background as configured. The current file is only closed once the new file
+// has been opened so that it can still be written to if rotation fails. It must be called with the
+// mutex held.
+func (f *logFile) rotate() error {
+	backup, err := f.backupPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("renaming log file: %w", err)
+	}
+
+	current := f.file
+	if err := f.open(); err != nil {
+		// Move the file back so that logs continue to be written to the path.
+		if renameErr := os.Rename(backup, f.path); renameErr != nil {
+			return errors.Join(err, fmt.Errorf("restoring log file: %w", renameErr))
+		}
+
+		return err
+	}
+
+	closeErr := current.Close()
+
+	f.cleanups.Add(1)
+
+	go f.cleanup(backup)
+
+	if closeErr != nil {
+		return fmt.Errorf("closing log file: %w", closeErr)
+	}
+
+	return nil
+}
+
+// backupPath returns the path to rename the file to when it is rotated. A sequence number is added
+// when a backup, compressed or not, already exists for the time of rotation.
+func (f *logFile) backupPath() (string, error) {
+	ext := filepath.Ext(f.path)
+	base := fmt.Sprintf("%s-%s", strings.TrimSuffix(f.path, ext), f.now().UTC().Format(backupTimeFormat))
+
+	for seq := 0; ; seq++ {
+		backup := base + ext
+		if seq > 0 {
+			backup = fmt.Sprintf("%s-%d%s", base, seq, ext)
+		}
+
+		exists, err := anyExists(backup, backup+compressSuffix)
+		if err != nil {
+			return "", fmt.Errorf("checking log file backup: %w", err)
+		}
+
+		if !exists {
+			return backup, nil
+		}
+	}
+}
+
+// anyExists reports whether a file exists at any of the given paths.
+func anyExists(paths ...string) (bool, error) {
+	for _, path := range paths {
+		_, err := os.Lstat(path)
+		if err == nil {
+			return true, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// cleanup compresses the backup and removes old backups as configured, recording any error to be
+// returned by Close.
+func (f *logFile) cleanup(backup string) {
+	defer f.cleanups.Done()
+
+	f.cleanupMu.Lock()
+	defer f.cleanupMu.Unlock()
+
+	var errs []error
+
+	if f.rotation.Compress {
+		errs = append(errs, compressFile(backup))
+	}
+
+	errs = append(errs, f.removeOldBackups())
+
+	if err := errors.Join(errs...); err != nil {
+		f.cleanupErr = err
+	}
+}
+
+// removeOldBackups deletes the oldest rotated files so that at most MaxBackups are kept.
+func (f *logFile) removeOldBackups() error {
+	if f.rotation.MaxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for len(backups) > f.rotation.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			errs = append(errs, fmt.Errorf("removing log file backup: %w", err))
+		}
+
+		backups = backups[1:]
+	}
+
+	return errors.Join(errs...)
+}
+
+// backups returns the paths of the rotated files, oldest first.
+func (f *logFile) backups() ([]string, error) {
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(filepath.Base(f.path), ext) + "-"
+
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return nil, fmt.Errorf("reading log file directory: %w", err)
+	}
+
+	type backup struct {
+		path string
+		time time.Time
+		seq  int
+	}
+
+	var found []backup
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		rotatedAt, seq, ok := parseBackupSuffix(strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, prefix), compressSuffix), ext))
+		if !ok {
+			continue // Not a backup created by rotation.
+		}
+
+		found = append(found, backup{path: filepath.Join(filepath.Dir(f.path), name), time: rotatedAt, seq: seq})
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].time.Equal(found[j].time) {
+			return found[i].seq < found[j].seq
+		}
+
+		return found[i].time.Before(found[j].time)
+	})
+
+	backups := make([]string, len(found))
+	for i, b := range found {
+		backups[i] = b.path
+	}
+
+	return backups, nil
+}
+
+// parseBackupSuffix parses the time of rotation and the optional sequence number added to the name
+// of a rotated file, returning false if the suffix was not created by rotation.
+func parseBackupSuffix(suffix string) (time.Time, int, bool) {
+	if rotatedAt, err := time.Parse(backupTimeFormat, suffix); err == nil {
+		return rotatedAt, 0, true
+	}
+
+	sep := strings.LastIndex(suffix, "-")
+	if sep < 0 {
+		return time.Time{}, 0, false
+	}
+
+	seq, err := strconv.Atoi(suffix[sep+1:])
+	if err != nil || seq <= 0 {
+		return time.Time{}, 0, false
+	}
+
+	rotatedAt, err := time.Parse(backupTimeFormat, suffix[:sep])
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+
+	return rotatedAt, seq, true
+}
+
+// compressFile writes a gzip compressed copy of the file at path to path.gz and removes the original.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening log file backup: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, ownerRWOnly)
+	if err != nil {
+		return fmt.Errorf("creating compressed log file backup: %w", err)
+	}
+
+	writer := gzip.NewWriter(dst)
+
+	if _, err := io.Copy(writer, src); err != nil {
+		_ = dst.Close() // The error from copying is more useful to the caller.
+
+		return fmt.Errorf("compressing log file backup: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		_ = dst.Close() // The error from flushing is more useful to the caller.
+
+		return fmt.Errorf("compressing log file backup: %w", err)
+	}
+
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("closing compressed log file backup: %w", err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("removing uncompressed log file backup: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package lgr
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a time that advances by a second each time it is called so that each rotated file
+// has a unique name.
+func fakeClock() func() time.Time {
+	now := time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	return func() time.Time {
+		now = now.Add(time.Second)
+
+		return now
+	}
+}
+
+func openTestLogFile(t *testing.T, rotation Rotation) (*logFile, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	file, err := openLogFile(path, rotation)
+	require.NoError(t, err)
+
+	file.now = fakeClock()
+	file.openedAt = file.now()
+
+	t.Cleanup(func() { assert.NoError(t, file.Close()) })
+
+	return file, path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return string(contents)
+}
+
+func TestLogFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rotates by size and keeps max backups", func(t *testing.T) {
+		t.Parallel()
+
+		file, path := openTestLogFile(t, Rotation{MaxSize: 10, MaxAge: 0, MaxBackups: 2, Compress: false, ReopenOnSIGHUP: false})
+
+		for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+			_, err := file.Write([]byte(line))
+			require.NoError(t, err)
+		}
+
+		file.cleanups.Wait()
+
+		backups, err := file.backups()
+		require.NoError(t, err)
+		require.Len(t, backups, 2)
+
+		assert.Equal(t, "second\n", readFile(t, backups[0]))
+		assert.Equal(t, "third\n", readFile(t, backups[1]))
+		assert.Equal(t, "fourth\n", readFile(t, path))
+	})
+
+	t.Run("rotates by age", func(t *testing.T) {
+		t.Parallel()
+
+		file, path := openTestLogFile(t, Rotation{MaxSize: 0, MaxAge: time.Minute, MaxBackups: 0, Compress: false, ReopenOnSIGHUP: false})
+
+		_, err := file.Write([]byte("first\n"))
+		require.NoError(t, err)
+
+		file.openedAt = file.openedAt.Add(-time.Minute)
+
+		_, err = file.Write([]byte("second\n"))
+		require.NoError(t, err)
+
+		file.cleanups.Wait()
+
+		backups, err := file.backups()
+		require.NoError(t, err)
+		require.Len(t, backups, 1)
+
+		assert.Equal(t, "first\n", readFile(t, backups[0]))
+		assert.Equal(t, "second\n", readFile(t, path))
+	})
+
+	t.Run("compresses rotated files", func(t *testing.T) {
+		t.Parallel()
+
+		file, _ := openTestLogFile(t, Rotation{MaxSize: 1, MaxAge: 0, MaxBackups: 0, Compress: true, ReopenOnSIGHUP: false})
+
+		for _, line := range []string{"first\n", "second\n"} {
+			_, err := file.Write([]byte(line))
+			require.NoError(t, err)
+		}
+
+		file.cleanups.Wait()
+
+		backups, err := file.backups()
+		require.NoError(t, err)
+		require.Len(t, backups, 1)
+		assert.Equal(t, compressSuffix, filepath.Ext(backups[0]))
+
+		compressed, err := os.Open(backups[0])
+		require.NoError(t, err)
+
+		defer compressed.Close()
+
+		reader, err := gzip.NewReader(compressed)
+		require.NoError(t, err)
+
+		contents, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "first\n", string(contents))
+	})
+
+	t.Run("adds a sequence number to files rotated at the same time", func(t *testing.T) {
+		t.Parallel()
+
+		file, path := openTestLogFile(t, Rotation{MaxSize: 1, MaxAge: 0, MaxBackups: 0, Compress: false, ReopenOnSIGHUP: false})
+
+		now := file.now()
+		file.now = func() time.Time { return now }
+
+		for _, line := range []string{"first\n", "second\n", "third\n"} {
+			_, err := file.Write([]byte(line))
+			require.NoError(t, err)
+		}
+
+		file.cleanups.Wait()
+
+		backups, err := file.backups()
+		require.NoError(t, err)
+		require.Len(t, backups, 2)
+
+		assert.Equal(t, "first\n", readFile(t, backups[0]))
+		assert.Equal(t, "second\n", readFile(t, backups[1]))
+		assert.True(t, strings.HasSuffix(backups[1], "-1.log"), backups[1])
+		assert.Equal(t, "third\n", readFile(t, path))
+	})
+
+	t.Run("keeps writing and rotates again when rotation fails", func(t *testing.T) {
+		t.Parallel()
+
+		file, path := openTestLogFile(t, Rotation{MaxSize: 1, MaxAge: 0, MaxBackups: 0, Compress: false, ReopenOnSIGHUP: false})
+
+		_, err := file.Write([]byte("first\n"))
+		require.NoError(t, err)
+
+		// Removing the file makes renaming it during rotation fail.
+		require.NoError(t, os.Remove(path))
+
+		n, err := file.Write([]byte("second\n"))
+		require.Error(t, err)
+		assert.Equal(t, len("second\n"), n)
+
+		require.NoError(t, os.WriteFile(path, []byte("restored\n"), ownerRWOnly))
+
+		_, err = file.Write([]byte("third\n"))
+		require.NoError(t, err)
+
+		file.cleanups.Wait()
+
+		backups, err := file.backups()
+		require.NoError(t, err)
+		require.Len(t, backups, 1)
+
+		assert.Equal(t, "restored\n", readFile(t, backups[0]))
+		assert.Equal(t, "third\n", readFile(t, path))
+	})
+
+	t.Run("creates files readable only by the owner", func(t *testing.T) {
+		t.Parallel()
+
+		_, path := openTestLogFile(t, Rotation{MaxSize: 0, MaxAge: 0, MaxBackups: 0, Compress: false, ReopenOnSIGHUP: false})
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(ownerRWOnly), info.Mode().Perm())
+	})
+
+	t.Run("does not listen for SIGHUP by default", func(t *testing.T) {
+		t.Parallel()
+
+		file, _ := openTestLogFile(t, Rotation{MaxSize: 0, MaxAge: 0, MaxBackups: 0, Compress: false, ReopenOnSIGHUP: false})
+
+		assert.Nil(t, file.signals)
+	})
+
+	t.Run("reopens the file on SIGHUP", func(t *testing.T) {
+		t.Parallel()
+
+		file, path := openTestLogFile(t, Rotation{MaxSize: 0, MaxAge: 0, MaxBackups: 0, Compress: false, ReopenOnSIGHUP: true})
+
+		_, err := file.Write([]byte("first\n"))
+		require.NoError(t, err)
+
+		moved := path + ".1"
+		require.NoError(t, os.Rename(path, moved))
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(path)
+
+			return err == nil
+		}, time.Second, time.Millisecond)
+
+		_, err = file.Write([]byte("second\n"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "first\n", readFile(t, moved))
+		assert.Equal(t, "second\n", readFile(t, path))
+	})
+
+	t.Run("returns an error when writing after close", func(t *testing.T) {
+		t.Parallel()
+
+		file, _ := openTestLogFile(t, Rotation{MaxSize: 0, MaxAge: 0, MaxBackups: 0, Compress: false, ReopenOnSIGHUP: false})
+		require.NoError(t, file.Close())
+
+		_, err := file.Write([]byte("first\n"))
+		assert.ErrorIs(t, err, os.ErrClosed)
+	})
+}
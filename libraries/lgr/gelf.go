@@ -0,0 +1,443 @@
+package lgr
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidGELFAddress is returned when the path of a FormatGELF Output is not a udp:// or tcp:// address.
+var ErrInvalidGELFAddress = errors.New("invalid GELF address")
+
+// ErrGELFMessageTooLarge is returned when a GELF message would need more chunks than allowed by the spec.
+var ErrGELFMessageTooLarge = errors.New("GELF message too large")
+
+const (
+	// gelfVersion is the version of the GELF spec that is implemented.
+	gelfVersion = "1.1"
+	// gelfChunkSize is the maximum size of a UDP datagram, leaving room for the headers of a typical
+	// network within the MTU of 1500 bytes.
+	gelfChunkSize = 1420
+	// gelfChunkHeaderSize is the size of the magic bytes, message ID, sequence number and sequence count.
+	gelfChunkHeaderSize = 12
+	// gelfMaxChunks is the maximum number of chunks that a message can be split into.
+	gelfMaxChunks = 128
+	// gelfMinRedialBackoff is how long to wait before dialing the GELF server again after the first
+	// failed attempt. The wait doubles after each failed attempt up to gelfMaxRedialBackoff.
+	gelfMinRedialBackoff = 100 * time.Millisecond
+	// gelfMaxRedialBackoff is the longest wait between attempts to dial the GELF server.
+	gelfMaxRedialBackoff = 30 * time.Second
+	// gelfDialTimeout is how long to wait for the connection to the GELF server to be made.
+	gelfDialTimeout = 5 * time.Second
+	// gelfWriteTimeout is how long to wait for a message to be written to the GELF server so that a
+	// server that stops reading does not block logging.
+	gelfWriteTimeout = 5 * time.Second
+)
+
+// gelfChunkMagic are the bytes that identify a chunked GELF message.
+var gelfChunkMagic = [2]byte{0x1e, 0x0f}
+
+// gelfInvalidKeyChars matches the characters that are not allowed in the name of a GELF additional field.
+var gelfInvalidKeyChars = regexp.MustCompile(`[^\w.\-]`)
+
+// gelfEncoder encodes entries as GELF 1.1 messages. Fields are written as additional fields, prefixed
+// with an "_", with the keys of objects and groups joined by a ".". As GELF only supports string and
+// number values, all other values are written as strings.
+type gelfEncoder struct {
+	host string
+	// newline terminates each message with a newline for when messages are written to a stream, such as
+	// a file, rather than sent to a GELF server.
+	newline bool
+}
+
+// newGELFEncoder creates a gelfEncoder that identifies the host by the given host, or by its hostname
+// when the host is empty.
+func newGELFEncoder(host string, newline bool) gelfEncoder {
+	if host == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "unknown"
+		}
+
+		host = hostname
+	}
+
+	return gelfEncoder{host: host, newline: newline}
+}
+
+// NewGELFAdapter creates an Adapter that sends logs as GELF 1.1 messages to the Graylog server at the
+// given address, which must be in the form udp://host:port or tcp://host:port. The connection is made
+// when the first message is sent, so the server does not need to be available when the adapter is
+// created, and is made again, with backoff, when it can not be made or a message can not be sent. The
+// adapter must be closed to close the connection.
+func NewGELFAdapter(address string) (Adapter, error) { //nolint: ireturn // The adapter is only used through the interface.
+	return newGELFAdapter(address, "")
+}
+
+// newGELFAdapter creates an Adapter that sends logs to the GELF server at the given address identifying
+// the host by the given host, or by its hostname when the host is empty.
+func newGELFAdapter(address string, host string) (Adapter, error) { //nolint: ireturn // The adapter is only used through the interface.
+	writer, err := dialGELF(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLineAdapter(writer, writer, newGELFEncoder(host, false)), nil
+}
+
+func (e gelfEncoder) encode(buf *bytes.Buffer, entry Entry) {
+	buf.WriteString(`{"version":"` + gelfVersion + `","host":`)
+	writeJSONString(buf, e.host)
+	buf.WriteString(`,"short_message":`)
+	writeJSONString(buf, entry.Message)
+
+	if len(entry.Stack) > 0 {
+		buf.WriteString(`,"full_message":`)
+		writeJSONString(buf, entry.Message+"\n"+strings.Join(entry.Stack, "\n"))
+	}
+
+	if !entry.Time.IsZero() {
+		buf.WriteString(`,"timestamp":`)
+		buf.WriteString(strconv.FormatFloat(float64(entry.Time.UnixNano())/float64(time.Second), 'f', 3, 64))
+	}
+
+	buf.WriteString(`,"level":`)
+	buf.WriteString(strconv.Itoa(gelfLevel(entry.Level)))
+
+	if entry.LoggerName != "" {
+		buf.WriteString(`,"_logger":`)
+		writeJSONString(buf, entry.LoggerName)
+	}
+
+	if entry.Caller.Defined {
+		buf.WriteString(`,"_caller":`)
+		writeJSONString(buf, entry.Caller.String())
+	}
+
+	e.encodeFields(buf, "", entry.Fields)
+
+	buf.WriteByte('}')
+
+	if e.newline {
+		buf.WriteByte('\n')
+	}
+}
+
+func (e gelfEncoder) encodeFields(buf *bytes.Buffer, prefix string, fields []Field) {
+	for _, field := range fields {
+		key := prefix + gelfInvalidKeyChars.ReplaceAllString(field.Key, "_")
+
+		switch field.Type { //nolint: exhaustive // All other types are written as strings.
+		case ObjectType:
+			// Nil objects are written as strings.
+			if object, ok := field.Interface.(ObjectMarshaler); ok {
+				e.encodeFields(buf, key+".", object.MarshalLogObject())
+
+				continue
+			}
+		case GroupType:
+			e.encodeFields(buf, key+".", field.Interface.([]Field)) //nolint: forcetypeassert // We know the type.
+
+			continue
+		}
+
+		// The "_id" field is reserved by the GELF spec.
+		if key == "id" {
+			key = "id_"
+		}
+
+		buf.WriteString(`,"_`)
+		buf.WriteString(key)
+		buf.WriteString(`":`)
+
+		switch field.Type { //nolint: exhaustive // All other types are written as strings.
+		case Float32Type, Float64Type:
+			value := fmt.Sprint(field.Value())
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				// NaN and infinity can not be written as JSON numbers.
+				writeJSONString(buf, value)
+			} else {
+				buf.WriteString(value)
+			}
+		case IntType, Int8Type, Int16Type, Int32Type, Int64Type,
+			UintType, Uint8Type, Uint16Type, Uint32Type, Uint64Type, UintptrType:
+			buf.WriteString(fmt.Sprint(field.Value()))
+		case ArrayType:
+			writeJSONString(buf, formatFieldValue(field))
+		case TimeType:
+			writeJSONString(buf, field.timeValue().Format(time.RFC3339Nano))
+		default:
+			writeJSONString(buf, fmt.Sprint(fieldToValue(field)))
+		}
+	}
+}
+
+// gelfLevel returns the syslog severity of the level.
+func gelfLevel(level Level) int {
+	const (
+		alert    = 1
+		critical = 2
+		err      = 3
+		warning  = 4
+		info     = 6
+		debug    = 7
+	)
+
+	switch level {
+	case DebugLevel:
+		return debug
+	case InfoLevel:
+		return info
+	case WarnLevel:
+		return warning
+	case ErrorLevel:
+		return err
+	case PanicLevel:
+		return critical
+	case FatalLevel:
+		return alert
+	default:
+		return info
+	}
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	encoded, _ := json.Marshal(s) // Encoding a string can not fail.
+	buf.Write(encoded)
+}
+
+// gelfWriter sends GELF messages to a Graylog server. Over UDP, messages larger than a single datagram
+// are split into chunks. Over TCP, messages are delimited by a null byte. The connection is dialed
+// again when a message can not be sent, waiting longer after each failed dial or timed out write so that
+// an unavailable server is not dialed for every message.
+type gelfWriter struct {
+	mu           sync.Mutex
+	network      string
+	host         string
+	dial         func(network, address string) (net.Conn, error)
+	now          func() time.Time
+	writeTimeout time.Duration
+	conn         net.Conn
+	dialErr      error
+	backoff      time.Duration
+	nextDial     time.Time
+	closed       bool
+	chunkSize    int
+	messageID    func() ([8]byte, error)
+}
+
+// dialGELF creates a gelfWriter for the GELF server at the given address, which must be in the form
+// udp://host:port or tcp://host:port. The server is dialed when the first message is written so that
+// an unavailable server does not block the creation of the logger.
+func dialGELF(address string) (*gelfWriter, error) {
+	network, host, err := parseGELFAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gelfWriter{
+		mu:      sync.Mutex{},
+		network: network,
+		host:    host,
+		dial: func(network, address string) (net.Conn, error) {
+			return net.DialTimeout(network, address, gelfDialTimeout)
+		},
+		now:          time.Now,
+		writeTimeout: gelfWriteTimeout,
+		conn:         nil,
+		dialErr:      nil,
+		backoff:      0,
+		nextDial:     time.Time{},
+		closed:       false,
+		chunkSize:    gelfChunkSize,
+		messageID:    randomMessageID,
+	}, nil
+}
+
+// parseGELFAddress returns the network and host of the GELF server at the given address, which must be
+// in the form udp://host:port or tcp://host:port.
+func parseGELFAddress(address string) (string, string, error) {
+	parsed, err := url.Parse(address)
+	if err != nil || (parsed.Scheme != "udp" && parsed.Scheme != "tcp") || parsed.Host == "" {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidGELFAddress, address)
+	}
+
+	return parsed.Scheme, parsed.Host, nil
+}
+
+// Write sends p as a single GELF message. If the message can not be sent the connection is dialed
+// again and the message is sent once more, unless the write timed out as the server is then likely to
+// be stalled and is not dialed again until the backoff has passed.
+func (w *gelfWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, fmt.Errorf("writing GELF message: %w", net.ErrClosed)
+	}
+
+	packets, err := w.packets(p)
+	if err != nil {
+		return 0, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		conn, err := w.connect()
+		if err != nil {
+			return 0, err
+		}
+
+		err = writePackets(conn, w.now().Add(w.writeTimeout), packets)
+		if err == nil {
+			return len(p), nil
+		}
+
+		_ = conn.Close() // The connection is broken so the error from writing is more useful.
+		w.conn = nil
+
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			w.fail(err)
+
+			return 0, err
+		}
+
+		if attempt > 0 {
+			return 0, err
+		}
+	}
+}
+
+// connect returns the connection to the GELF server, dialing it if there is no connection. Once a dial
+// has failed the server is not dialed again until the backoff has passed.
+func (w *gelfWriter) connect() (net.Conn, error) {
+	if w.conn != nil {
+		return w.conn, nil
+	}
+
+	now := w.now()
+	if now.Before(w.nextDial) {
+		return nil, fmt.Errorf("dialing GELF server: %w", w.dialErr)
+	}
+
+	conn, err := w.dial(w.network, w.host)
+	if err != nil {
+		w.fail(err)
+
+		return nil, fmt.Errorf("dialing GELF server: %w", err)
+	}
+
+	w.conn, w.dialErr, w.backoff, w.nextDial = conn, nil, 0, time.Time{}
+
+	return conn, nil
+}
+
+// fail records that the GELF server could not be reached so that it is not dialed again until the
+// backoff, which doubles after each failure, has passed.
+func (w *gelfWriter) fail(err error) {
+	w.dialErr = err
+	w.backoff = min(max(2*w.backoff, gelfMinRedialBackoff), gelfMaxRedialBackoff)
+	w.nextDial = w.now().Add(w.backoff)
+}
+
+// packets returns the packets that p is sent as. Over TCP and for small UDP messages this is a single
+// packet. Larger UDP messages are split into chunks that share a message ID.
+func (w *gelfWriter) packets(p []byte) ([][]byte, error) {
+	if w.network == "tcp" {
+		return [][]byte{append(p[:len(p):len(p)], 0)}, nil
+	}
+
+	if len(p) <= w.chunkSize {
+		return [][]byte{p}, nil
+	}
+
+	dataSize := w.chunkSize - gelfChunkHeaderSize
+	count := (len(p) + dataSize - 1) / dataSize
+
+	if count > gelfMaxChunks {
+		return nil, fmt.Errorf("%w: %d chunks", ErrGELFMessageTooLarge, count)
+	}
+
+	id, err := w.messageID()
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([][]byte, count)
+
+	for i := range chunks {
+		end := (i + 1) * dataSize
+		if end > len(p) {
+			end = len(p)
+		}
+
+		chunk := make([]byte, 0, gelfChunkHeaderSize+end-i*dataSize)
+		chunk = append(chunk, gelfChunkMagic[:]...)
+		chunk = append(chunk, id[:]...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunks[i] = append(chunk, p[i*dataSize:end]...)
+	}
+
+	return chunks, nil
+}
+
+// writePackets writes each packet to the connection, failing if they have not been written by the
+// deadline.
+func writePackets(conn net.Conn, deadline time.Time, packets [][]byte) error {
+	if err := conn.SetWriteDeadline(deadline); err != nil {
+		return fmt.Errorf("setting GELF write deadline: %w", err)
+	}
+
+	for _, packet := range packets {
+		if _, err := conn.Write(packet); err != nil {
+			return fmt.Errorf("writing GELF message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Sync is a no-op as messages are sent as soon as they are written.
+func (w *gelfWriter) Sync() error {
+	return nil
+}
+
+// Close closes the connection to the GELF server. The server is not dialed again after Close.
+func (w *gelfWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+
+	if err != nil {
+		return fmt.Errorf("closing GELF connection: %w", err)
+	}
+
+	return nil
+}
+
+func randomMessageID() ([8]byte, error) {
+	var id [8]byte
+
+	if _, err := rand.Read(id[:]); err != nil {
+		return id, fmt.Errorf("generating GELF message ID: %w", err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,423 @@
+package lgr //nolint: testpackage // Tests the unexported encoder and transport.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGELFAdapter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends every field type over UDP", func(t *testing.T) {
+		t.Parallel()
+
+		conn := listenUDP(t)
+
+		writer, err := dialGELF("udp://" + conn.LocalAddr().String())
+		require.NoError(t, err)
+
+		logger := FromAdapter(
+			newLineAdapter(writer, writer, gelfEncoder{host: "test-host", newline: false}),
+			WithMinLevel(InfoLevel),
+			WithTimestampFactory(func() time.Time { return time.Date(2022, time.March, 5, 0, 0, 0, 123e6, time.UTC) }),
+		).Named("iam").Named("identity")
+
+		logger.Debug("my debug message")
+		logger.Info("my info message", everyFieldType()...)
+		logger.Warn("my warn message")
+		logger.Error("my error message", Str("requestId", "abc-123"))
+		require.NoError(t, logger.Close())
+
+		messages := make([]string, 0, 3)
+		for i := 0; i < 3; i++ {
+			messages = append(messages, string(readDatagram(t, conn)))
+		}
+
+		assertGolden(t, "gelf.golden", []byte(strings.Join(messages, "\n")+"\n"))
+	})
+
+	t.Run("sends messages over TCP delimited by a null byte", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = listener.Close() })
+
+		adapter, err := NewGELFAdapter("tcp://" + listener.Addr().String())
+		require.NoError(t, err)
+
+		logger := FromAdapter(adapter)
+		logger.Info("first message")
+		logger.Warn("second message")
+		require.NoError(t, logger.Close())
+
+		// The connection is made by the first message and is queued by the listener until accepted.
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		reader := bufio.NewReader(conn)
+
+		for _, want := range []string{"first message", "second message"} {
+			frame, err := reader.ReadBytes(0)
+			require.NoError(t, err)
+
+			var message map[string]any
+			require.NoError(t, json.Unmarshal(frame[:len(frame)-1], &message))
+			assert.Equal(t, want, message["short_message"])
+		}
+	})
+
+	t.Run("writes the stack as the full message", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		gelfEncoder{host: "test-host", newline: false}.encode(&buf, Entry{
+			Level:   FatalLevel,
+			Message: "my fatal message",
+			Caller:  Caller{Defined: true, PC: 1, Function: "main.main", File: "/app/main.go", Line: 42},
+			Stack:   []string{"main.main /app/main.go:42"},
+		})
+
+		assert.Equal(t,
+			`{"version":"1.1","host":"test-host","short_message":"my fatal message",`+
+				`"full_message":"my fatal message\nmain.main /app/main.go:42","level":1,"_caller":"/app/main.go:42"}`,
+			buf.String(),
+		)
+	})
+
+	t.Run("does not return an error when the server is unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewGELFAdapter("tcp://" + closedTCPAddress(t))
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns an error for an invalid address", func(t *testing.T) {
+		t.Parallel()
+
+		for _, address := range []string{"stderr", "http://localhost:12201", "udp://"} {
+			_, err := NewGELFAdapter(address)
+			assert.ErrorIs(t, err, ErrInvalidGELFAddress, address)
+		}
+	})
+}
+
+func TestGELFWriterChunking(t *testing.T) {
+	t.Parallel()
+
+	t.Run("splits large messages into chunks", func(t *testing.T) {
+		t.Parallel()
+
+		conn := listenUDP(t)
+
+		writer, err := dialGELF("udp://" + conn.LocalAddr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = writer.Close() })
+
+		writer.chunkSize = 32
+		writer.messageID = sequentialMessageID()
+
+		message := []byte(strings.Repeat("0123456789", 10))
+
+		n, err := writer.Write(message)
+		require.NoError(t, err)
+		assert.Equal(t, len(message), n)
+
+		// 100 bytes with 20 bytes of data in each chunk.
+		const count = 5
+
+		var reassembled []byte
+
+		for i := 0; i < count; i++ {
+			chunk := readDatagram(t, conn)
+
+			require.Greater(t, len(chunk), gelfChunkHeaderSize)
+			assert.Equal(t, gelfChunkMagic[:], chunk[:2])
+			assert.Equal(t, uint64(1), binary.BigEndian.Uint64(chunk[2:10]))
+			assert.Equal(t, byte(i), chunk[10])
+			assert.Equal(t, byte(count), chunk[11])
+
+			reassembled = append(reassembled, chunk[gelfChunkHeaderSize:]...)
+		}
+
+		assert.Equal(t, message, reassembled)
+	})
+
+	t.Run("sends small messages in a single datagram", func(t *testing.T) {
+		t.Parallel()
+
+		conn := listenUDP(t)
+
+		writer, err := dialGELF("udp://" + conn.LocalAddr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = writer.Close() })
+
+		_, err = writer.Write([]byte(`{"short_message":"hello"}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"short_message":"hello"}`, string(readDatagram(t, conn)))
+	})
+
+	t.Run("returns an error when a message needs too many chunks", func(t *testing.T) {
+		t.Parallel()
+
+		conn := listenUDP(t)
+
+		writer, err := dialGELF("udp://" + conn.LocalAddr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = writer.Close() })
+
+		writer.chunkSize = gelfChunkHeaderSize + 1
+
+		_, err = writer.Write(make([]byte, gelfMaxChunks+1))
+		assert.ErrorIs(t, err, ErrGELFMessageTooLarge)
+	})
+}
+
+func TestGELFWriterReconnect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dials again when the connection drops", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = listener.Close() })
+
+		accepted := make(chan net.Conn, 2)
+
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+
+				accepted <- conn
+			}
+		}()
+
+		writer, err := dialGELF("tcp://" + listener.Addr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = writer.Close() })
+
+		_, err = writer.Write([]byte("before drop"))
+		require.NoError(t, err)
+		require.NoError(t, (<-accepted).Close())
+
+		// Writes to a dropped TCP connection can succeed until the peer has reset it.
+		var redialed net.Conn
+
+		require.Eventually(t, func() bool {
+			_, _ = writer.Write([]byte("after drop"))
+
+			select {
+			case redialed = <-accepted:
+				return true
+			default:
+				return false
+			}
+		}, time.Second, time.Millisecond)
+
+		t.Cleanup(func() { _ = redialed.Close() })
+
+		_, err = writer.Write([]byte("after redial"))
+		require.NoError(t, err)
+
+		reader := bufio.NewReader(redialed)
+
+		for {
+			frame, err := reader.ReadBytes(0)
+			require.NoError(t, err)
+
+			if string(frame) == "after redial\x00" {
+				break
+			}
+		}
+	})
+
+	t.Run("waits before dialing again after a failed dial", func(t *testing.T) {
+		t.Parallel()
+
+		writer, err := dialGELF("tcp://" + closedTCPAddress(t))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = writer.Close() })
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = listener.Close() })
+
+		now := time.Now()
+		writer.now = func() time.Time { return now }
+
+		_, err = writer.Write([]byte("unavailable"))
+		require.Error(t, err)
+
+		dials := 0
+		writer.dial = func(network, _ string) (net.Conn, error) {
+			dials++
+
+			return net.Dial(network, listener.Addr().String())
+		}
+
+		_, err = writer.Write([]byte("during backoff"))
+		require.Error(t, err)
+		assert.Equal(t, 0, dials)
+
+		now = now.Add(gelfMinRedialBackoff)
+
+		_, err = writer.Write([]byte("after backoff"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, dials)
+
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		frame, err := bufio.NewReader(conn).ReadBytes(0)
+		require.NoError(t, err)
+		assert.Equal(t, "after backoff\x00", string(frame))
+	})
+}
+
+func TestGELFWriterTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("does not dial the server until a message is written", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = listener.Close() })
+
+		writer, err := dialGELF("tcp://" + listener.Addr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = writer.Close() })
+
+		require.NoError(t, listener.SetDeadline(time.Now().Add(50*time.Millisecond)))
+
+		_, err = listener.Accept()
+		require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+		require.NoError(t, listener.SetDeadline(time.Time{}))
+
+		_, err = writer.Write([]byte("first message"))
+		require.NoError(t, err)
+
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+	})
+
+	t.Run("stops waiting for a server that does not read", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = listener.Close() })
+
+		accepted := make(chan net.Conn, 1)
+
+		go func() {
+			// The connection is accepted but never read so that the socket buffers fill up.
+			conn, err := listener.Accept()
+			if err == nil {
+				accepted <- conn
+			}
+		}()
+
+		writer, err := dialGELF("tcp://" + listener.Addr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = writer.Close() })
+
+		writer.writeTimeout = 50 * time.Millisecond
+
+		message := bytes.Repeat([]byte("a"), 1<<20)
+		started := time.Now()
+
+		for err == nil {
+			require.Less(t, time.Since(started), 5*time.Second, "the write was not timed out")
+
+			_, err = writer.Write(message)
+		}
+
+		require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+		require.NoError(t, (<-accepted).Close())
+
+		dials := 0
+		writer.dial = func(network, address string) (net.Conn, error) {
+			dials++
+
+			return net.Dial(network, address)
+		}
+
+		_, err = writer.Write([]byte("during backoff"))
+		require.Error(t, err)
+		assert.Equal(t, 0, dials)
+	})
+}
+
+// closedTCPAddress returns the address of a TCP listener that has been closed so that dialing it fails.
+func closedTCPAddress(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	return address
+}
+
+// listenUDP listens for datagrams on a random local port until the test completes.
+func listenUDP(t *testing.T) *net.UDPConn {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+// readDatagram reads the next datagram received by the connection, failing the test if none arrives.
+func readDatagram(t *testing.T, conn *net.UDPConn) []byte {
+	t.Helper()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	buf := make([]byte, gelfChunkSize)
+
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+
+	return buf[:n]
+}
+
+// sequentialMessageID returns a function that generates message IDs from a counter so that chunks can
+// be asserted on.
+func sequentialMessageID() func() ([8]byte, error) {
+	var counter uint64
+
+	return func() ([8]byte, error) {
+		var id [8]byte
+
+		counter++
+		binary.BigEndian.PutUint64(id[:], counter)
+
+		return id, nil
+	}
+}
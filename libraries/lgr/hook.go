@@ -0,0 +1,27 @@
+package lgr
+
+// Hook is called by a Logger for every entry that it writes, after the minimum level has been checked
+// and the bound fields have been merged and redacted. Hooks are called before the entry is passed to the
+// Adapter so they see every entry, even those later dropped by sampling or by the level of an Output.
+// Hooks are called synchronously and must be safe for concurrent use.
+type Hook interface {
+	// OnEntry is called with each entry written by the Logger. The entry must not be modified or
+	// retained after the call returns.
+	OnEntry(entry Entry)
+}
+
+// HookFunc is a function that can be used as a Hook.
+type HookFunc func(entry Entry)
+
+// OnEntry calls the function with the entry.
+func (f HookFunc) OnEntry(entry Entry) {
+	f(entry)
+}
+
+// WithHook adds hooks that are called for every entry written by the Logger, such as to count errors
+// for alerting. Hooks are called in the order that they were added.
+func WithHook(hooks ...Hook) Option {
+	return func(l *Logger) {
+		l.hooks = append(l.hooks[:len(l.hooks):len(l.hooks)], hooks...)
+	}
+}
@@ -0,0 +1,77 @@
+package lgr_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+func TestWithHook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("calls each hook with every entry written", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mu    sync.Mutex
+			calls []string
+		)
+
+		hook := func(name string) lgr.Hook {
+			return lgr.HookFunc(func(entry lgr.Entry) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				calls = append(calls, name+": "+entry.Message)
+			})
+		}
+
+		logger := lgr.FromAdapter(&entryRecorder{}, lgr.WithHook(hook("first")), lgr.WithHook(hook("second")))
+		logger.Info("my info message")
+		logger.Error("my error message")
+
+		assert.Equal(t, []string{
+			"first: my info message",
+			"second: my info message",
+			"first: my error message",
+			"second: my error message",
+		}, calls)
+	})
+
+	t.Run("is not called for entries below the min level", func(t *testing.T) {
+		t.Parallel()
+
+		var count int
+
+		logger := lgr.FromAdapter(&entryRecorder{},
+			lgr.WithMinLevel(lgr.WarnLevel),
+			lgr.WithHook(lgr.HookFunc(func(lgr.Entry) { count++ })),
+		)
+		logger.Info("my info message")
+		logger.Warn("my warn message")
+
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("receives the named, bound and redacted entry", func(t *testing.T) {
+		t.Parallel()
+
+		var got lgr.Entry
+
+		logger := lgr.FromAdapter(&entryRecorder{},
+			lgr.WithRedaction(lgr.RedactionRule{Keys: []string{"password"}, Mode: lgr.RedactMask}),
+			lgr.WithHook(lgr.HookFunc(func(entry lgr.Entry) { got = entry })),
+		).Named("iam").With(lgr.Str("requestId", "abc-123"))
+
+		logger.Error("my error message", lgr.Str("password", "hunter2"))
+
+		require.Equal(t, "iam", got.LoggerName)
+		assert.Equal(t, lgr.ErrorLevel, got.Level)
+		assert.Equal(t, []lgr.Field{lgr.Str("requestId", "abc-123"), lgr.Str("password", "[REDACTED]")}, got.Fields)
+		assert.Equal(t, 1, got.BoundFields)
+	})
+}
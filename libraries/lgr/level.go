@@ -0,0 +1,145 @@
+package lgr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrUnknownLevel is returned when a level name can not be parsed.
+var ErrUnknownLevel = errors.New("unknown level")
+
+// Leveler provides a Level. It is implemented by Level for a fixed minimum level and by LevelVar for a
+// minimum level that can be changed at runtime.
+type Leveler interface {
+	Level() Level
+}
+
+// Level returns the receiver so that a Level can be used as a Leveler.
+func (l Level) Level() Level {
+	return l
+}
+
+// String returns the lowercase name of the level, e.g. "info".
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case PanicLevel:
+		return "panic"
+	case FatalLevel:
+		return "fatal"
+	default:
+		return fmt.Sprintf("Level(%d)", l)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler by returning the name of the level.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing the case-insensitive name of a level.
+func (l *Level) UnmarshalText(text []byte) error {
+	for level := DebugLevel; level <= FatalLevel; level++ {
+		if strings.EqualFold(string(text), level.String()) {
+			*l = level
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnknownLevel, text)
+}
+
+// LevelVar is a Leveler that can be safely changed at runtime while it is being read by loggers. The zero
+// value of a LevelVar is InfoLevel. A LevelVar can be shared by many loggers by passing it to WithMinLevel.
+//
+// LevelVar implements http.Handler so that the level can be read and changed over HTTP:
+//
+//   - GET returns the current level as JSON, e.g. {"level":"info"}.
+//   - PUT sets the level from a JSON body in the same format and returns the new level.
+type LevelVar struct {
+	level atomic.Int32
+}
+
+// NewLevelVar creates a new LevelVar that is set to the given level.
+func NewLevelVar(level Level) *LevelVar {
+	var levelVar LevelVar
+	levelVar.Set(level)
+
+	return &levelVar
+}
+
+// Level returns the current level.
+func (v *LevelVar) Level() Level {
+	return Level(v.level.Load())
+}
+
+// Set changes the level.
+func (v *LevelVar) Set(level Level) {
+	v.level.Store(int32(level))
+}
+
+// String returns the name of the current level.
+func (v *LevelVar) String() string {
+	return fmt.Sprintf("LevelVar(%s)", v.Level())
+}
+
+// levelPayload is the JSON body read and written by LevelVar.ServeHTTP.
+type levelPayload struct {
+	Level *Level `json:"level"`
+}
+
+// levelError is the JSON body written by LevelVar.ServeHTTP when a request can not be handled.
+type levelError struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP reads or changes the level depending on the method of the request. See LevelVar for details.
+func (v *LevelVar) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		// Nothing to do as the current level is always written.
+	case http.MethodPut:
+		var payload levelPayload
+
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeLevelResponse(w, http.StatusBadRequest, levelError{Error: fmt.Sprintf("decoding request: %s", err)})
+
+			return
+		}
+
+		if payload.Level == nil {
+			writeLevelResponse(w, http.StatusBadRequest, levelError{Error: "must specify a level"})
+
+			return
+		}
+
+		v.Set(*payload.Level)
+	default:
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut)
+		writeLevelResponse(w, http.StatusMethodNotAllowed, levelError{Error: "only GET and PUT are supported"})
+
+		return
+	}
+
+	level := v.Level()
+	writeLevelResponse(w, http.StatusOK, levelPayload{Level: &level})
+}
+
+func writeLevelResponse(w http.ResponseWriter, status int, body any) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body) // There is nothing we can do if the client has gone away.
+}
@@ -0,0 +1,153 @@
+package lgr_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+func TestLevelText(t *testing.T) {
+	t.Parallel()
+
+	for _, level := range []lgr.Level{lgr.DebugLevel, lgr.InfoLevel, lgr.WarnLevel, lgr.ErrorLevel, lgr.PanicLevel, lgr.FatalLevel} {
+		text, err := level.MarshalText()
+		assert.NoError(t, err)
+
+		var got lgr.Level
+		assert.NoError(t, got.UnmarshalText(text))
+		assert.Equal(t, level, got)
+	}
+
+	var level lgr.Level
+	assert.NoError(t, level.UnmarshalText([]byte("WARN")))
+	assert.Equal(t, lgr.WarnLevel, level)
+
+	assert.ErrorIs(t, level.UnmarshalText([]byte("verbose")), lgr.ErrUnknownLevel)
+	assert.Equal(t, "Level(100)", lgr.Level(100).String())
+}
+
+func TestLevelVar(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults to info level", func(t *testing.T) {
+		t.Parallel()
+
+		var levelVar lgr.LevelVar
+		assert.Equal(t, lgr.InfoLevel, levelVar.Level())
+	})
+
+	t.Run("changes the minimum level of loggers at runtime", func(t *testing.T) {
+		t.Parallel()
+
+		levelVar := lgr.NewLevelVar(lgr.WarnLevel)
+		logger, entries := lgrtest.New(lgr.WithMinLevel(levelVar))
+		child := logger.Named("child")
+
+		logger.Info("my dropped info message")
+		levelVar.Set(lgr.DebugLevel)
+		logger.Debug("my debug message")
+		child.Debug("my child debug message")
+
+		assert.Len(t, entries.All(), 2)
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.DebugLevel, "my debug message")
+		lgrtest.AssertFullEntry(t, entries.Idx(1), lgr.DebugLevel, "my child debug message")
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		t.Parallel()
+
+		levelVar := lgr.NewLevelVar(lgr.InfoLevel)
+		logger, _ := lgr.New(lgr.WithOutputPath("stdout"), lgr.WithMinLevel(levelVar))
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+				levelVar.Set(lgr.ErrorLevel)
+			}()
+
+			go func() {
+				defer wg.Done()
+				logger.Debug("my debug message")
+			}()
+		}
+
+		wg.Wait()
+		assert.Equal(t, lgr.ErrorLevel, levelVar.Level())
+	})
+}
+
+func TestLevelVarServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		method     string
+		body       string
+		wantStatus int
+		wantBody   string
+		wantLevel  lgr.Level
+	}{
+		"gets the current level": {
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"level":"warn"}`,
+			wantLevel:  lgr.WarnLevel,
+		},
+		"sets the level": {
+			method:     http.MethodPut,
+			body:       `{"level":"debug"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"level":"debug"}`,
+			wantLevel:  lgr.DebugLevel,
+		},
+		"rejects an unknown level": {
+			method:     http.MethodPut,
+			body:       `{"level":"verbose"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"decoding request: unknown level: \"verbose\""}`,
+			wantLevel:  lgr.WarnLevel,
+		},
+		"rejects a missing level": {
+			method:     http.MethodPut,
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error":"must specify a level"}`,
+			wantLevel:  lgr.WarnLevel,
+		},
+		"rejects other methods": {
+			method:     http.MethodPost,
+			body:       `{"level":"debug"}`,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   `{"error":"only GET and PUT are supported"}`,
+			wantLevel:  lgr.WarnLevel,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			levelVar := lgr.NewLevelVar(lgr.WarnLevel)
+			recorder := httptest.NewRecorder()
+
+			levelVar.ServeHTTP(recorder, httptest.NewRequest(tc.method, "/log/level", strings.NewReader(tc.body)))
+
+			assert.Equal(t, tc.wantStatus, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.JSONEq(t, tc.wantBody, recorder.Body.String())
+			assert.Equal(t, tc.wantLevel, levelVar.Level())
+		})
+	}
+}
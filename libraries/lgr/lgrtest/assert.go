@@ -0,0 +1,106 @@
+package lgrtest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertContains asserts that at least one entry is matched by every one of the given matchers. When
+// no entry matches, the entries that were logged are included in the failure message.
+func AssertContains(t TestingT, entries *Entries, matchers ...Matcher) bool { //nolint: varnamelen // t is descriptive of the type.
+	if h, ok := t.(interface { // If we have a testing.T and not a mocked TestingT then tell Go this is a helper func.
+		Helper()
+	}); ok {
+		h.Helper()
+	}
+
+	all := entries.All()
+
+	for _, entry := range all {
+		if matchAll(entry, matchers) {
+			return true
+		}
+	}
+
+	return assert.Fail(
+		t,
+		fmt.Sprintf("no entry matched %s", describe(matchers)),
+		"logged entries:\n%s",
+		formatEntries(all),
+	)
+}
+
+// AssertNone asserts that no entry is matched by every one of the given matchers. When an entry
+// matches, the matching entries are included in the failure message.
+func AssertNone(t TestingT, entries *Entries, matchers ...Matcher) bool { //nolint: varnamelen // t is descriptive of the type.
+	if h, ok := t.(interface { // If we have a testing.T and not a mocked TestingT then tell Go this is a helper func.
+		Helper()
+	}); ok {
+		h.Helper()
+	}
+
+	matched := entries.Filter(matchers...)
+	if len(matched) == 0 {
+		return true
+	}
+
+	return assert.Fail(
+		t,
+		fmt.Sprintf("%d entries matched %s", len(matched), describe(matchers)),
+		"matching entries:\n%s",
+		formatEntries(matched),
+	)
+}
+
+// AssertSequence asserts that entries matching each of the given matchers were logged in the given
+// order. Other entries may be logged before, between and after the entries of the sequence. Use All to
+// match a step of the sequence on more than one property. When the sequence is not found, the failure
+// message names the first step that could not be matched and includes the entries that were logged,
+// marking the entries that matched the earlier steps.
+func AssertSequence(t TestingT, entries *Entries, sequence ...Matcher) bool { //nolint: varnamelen // t is descriptive of the type.
+	if h, ok := t.(interface { // If we have a testing.T and not a mocked TestingT then tell Go this is a helper func.
+		Helper()
+	}); ok {
+		h.Helper()
+	}
+
+	all := entries.All()
+	matchedAt := make(map[int]int, len(sequence))
+	step := 0
+
+	for i := 0; i < len(all) && step < len(sequence); i++ {
+		if sequence[step].Matches(all[i]) {
+			matchedAt[i] = step
+			step++
+		}
+	}
+
+	if step == len(sequence) {
+		return true
+	}
+
+	lines := formatEntries(all)
+	if len(all) > 0 {
+		marked := make([]string, len(all))
+
+		for i, entry := range all {
+			marker := "    "
+			if s, ok := matchedAt[i]; ok {
+				marker = fmt.Sprintf("%2d> ", s+1)
+			}
+
+			marked[i] = fmt.Sprintf("\t%s[%d] %s", marker, i, formatEntry(entry))
+		}
+
+		lines = strings.Join(marked, "\n")
+	}
+
+	return assert.Fail(
+		t,
+		fmt.Sprintf("sequence step %d (%s) was not matched after %d of %d steps", step+1, sequence[step], step, len(sequence)),
+		"logged entries, with matched steps marked:\n%s",
+		lines,
+	)
+}
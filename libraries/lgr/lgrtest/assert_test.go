@@ -0,0 +1,131 @@
+package lgrtest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+func TestAssertContains(t *testing.T) {
+	t.Parallel()
+
+	logger, entries := lgrtest.New()
+	logger.Info("request started", lgr.Str("requestId", "abc-123"))
+
+	t.Run("passes when an entry matches", func(t *testing.T) {
+		t.Parallel()
+
+		mockT := new(bufferT)
+		assert.True(t, lgrtest.AssertContains(mockT, entries, lgrtest.ByLevel(lgr.InfoLevel), lgrtest.HasField("requestId")))
+		assert.Empty(t, mockT.buf.String())
+	})
+
+	t.Run("prints the logged entries when no entry matches", func(t *testing.T) {
+		t.Parallel()
+
+		mockT := new(bufferT)
+		assert.False(t, lgrtest.AssertContains(mockT, entries, lgrtest.ByLevel(lgr.ErrorLevel)))
+		assert.Contains(t, mockT.buf.String(), "no entry matched level=error")
+		assert.Contains(t, mockT.buf.String(), `[0] level=info msg="request started" requestId=abc-123`)
+	})
+
+	t.Run("prints that nothing was logged", func(t *testing.T) {
+		t.Parallel()
+
+		_, empty := lgrtest.New()
+
+		mockT := new(bufferT)
+		assert.False(t, lgrtest.AssertContains(mockT, empty, lgrtest.ByMessage("request started")))
+		assert.Contains(t, mockT.buf.String(), "(no entries were logged)")
+	})
+}
+
+func TestAssertNone(t *testing.T) {
+	t.Parallel()
+
+	logger, entries := lgrtest.New()
+	logger.Info("request started")
+	logger.Error("request failed", lgr.Str("requestId", "abc-123"))
+
+	t.Run("passes when no entry matches", func(t *testing.T) {
+		t.Parallel()
+
+		mockT := new(bufferT)
+		assert.True(t, lgrtest.AssertNone(mockT, entries, lgrtest.ByLevel(lgr.WarnLevel)))
+		assert.Empty(t, mockT.buf.String())
+	})
+
+	t.Run("prints the matching entries", func(t *testing.T) {
+		t.Parallel()
+
+		mockT := new(bufferT)
+		assert.False(t, lgrtest.AssertNone(mockT, entries, lgrtest.ByLevel(lgr.ErrorLevel)))
+		assert.Contains(t, mockT.buf.String(), "1 entries matched level=error")
+		assert.Contains(t, mockT.buf.String(), `[0] level=error msg="request failed" requestId=abc-123`)
+		assert.NotContains(t, mockT.buf.String(), "request started")
+	})
+}
+
+func TestAssertSequence(t *testing.T) {
+	t.Parallel()
+
+	logger, entries := lgrtest.New()
+	logger.Info("request started")
+	logger.Debug("querying database")
+	logger.Error("query failed")
+	logger.Info("request finished")
+
+	testCases := map[string]struct {
+		sequence []lgrtest.Matcher
+		wantMsgs []string
+	}{
+		"passes when the entries are in order": {
+			sequence: []lgrtest.Matcher{
+				lgrtest.ByMessage("request started"),
+				lgrtest.All(lgrtest.ByLevel(lgr.ErrorLevel), lgrtest.ByMessage("query failed")),
+				lgrtest.ByMessage("request finished"),
+			},
+			wantMsgs: nil,
+		},
+		"fails when the entries are out of order": {
+			sequence: []lgrtest.Matcher{
+				lgrtest.ByMessage("request finished"),
+				lgrtest.ByMessage("request started"),
+			},
+			wantMsgs: []string{
+				`sequence step 2 (msg="request started") was not matched after 1 of 2 steps`,
+				` 1> [3] level=info msg="request finished"`,
+				`    [0] level=info msg="request started"`,
+			},
+		},
+		"fails when a step is missing": {
+			sequence: []lgrtest.Matcher{
+				lgrtest.ByMessage("request started"),
+				lgrtest.All(lgrtest.ByLevel(lgr.WarnLevel), lgrtest.ByMessage("query failed")),
+			},
+			wantMsgs: []string{`sequence step 2 (level=warn, msg="query failed") was not matched after 1 of 2 steps`},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			mockT := new(bufferT)
+			assert.Equal(t, len(tc.wantMsgs) == 0, lgrtest.AssertSequence(mockT, entries, tc.sequence...))
+
+			if len(tc.wantMsgs) == 0 {
+				assert.Empty(t, mockT.buf.String())
+			}
+
+			for _, want := range tc.wantMsgs {
+				assert.Contains(t, mockT.buf.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package lgrtest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+// Update rewrites the golden files asserted by AssertGolden with the rendered logs. A flag is not
+// registered by this package so that it does not clash with the flags of the test binary. Instead,
+// Update can be set from the flag of the test binary in TestMain:
+//
+//	var update = flag.Bool("update", false, "update the golden files in testdata")
+//
+//	func TestMain(m *testing.M) {
+//		flag.Parse()
+//		lgrtest.Update = *update
+//		os.Exit(m.Run())
+//	}
+//
+// The golden files are also rewritten when the LGRTEST_UPDATE environment variable is true, e.g.
+// LGRTEST_UPDATE=1 go test ./...
+var Update bool //nolint: gochecknoglobals // Set once by the test binary before the tests run.
+
+// UpdateEnv is the environment variable that rewrites the golden files when it is true.
+const UpdateEnv = "LGRTEST_UPDATE"
+
+// snapshotTime is the timestamp of every log rendered for a snapshot so that the output is deterministic.
+var snapshotTime = time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+// snapshotHost is the host of GELF messages rendered for a snapshot, unless another is configured.
+const snapshotHost = "lgrtest"
+
+// SnapshotOption configures how logs are rendered by Render and AssertGolden.
+type SnapshotOption func(s *snapshot)
+
+type snapshot struct {
+	loggerOpts    []lgr.Option
+	encoderConfig lgr.EncoderConfig
+	masks         map[string]fieldMask
+}
+
+// fieldMask replaces the values of a field that are matched by match with a placeholder.
+type fieldMask struct {
+	description string
+	match       func(value any) bool
+}
+
+// WithLoggerOptions applies the options to the Logger that the logs are written with, e.g.
+// lgr.WithMinLevel or lgr.WithRedaction. The fixed timestamp can be replaced with lgr.WithTimestampFactory.
+func WithLoggerOptions(opts ...lgr.Option) SnapshotOption {
+	return func(s *snapshot) {
+		s.loggerOpts = append(s.loggerOpts, opts...)
+	}
+}
+
+// WithEncoderConfig sets the lgr.EncoderConfig that the logs are rendered with.
+func WithEncoderConfig(config lgr.EncoderConfig) SnapshotOption {
+	return func(s *snapshot) {
+		s.encoderConfig = config
+	}
+}
+
+// MatchField replaces the value of the field with the given key by "<description>" when match returns
+// true for its value. This allows fields with nondeterministic values, such as generated IDs or
+// durations, to be snapshotted while still asserting on the shape of the value. When match returns
+// false the actual value is rendered so that the difference is shown by the failed assertion. Only
+// fields at the top level of the log are matched.
+func MatchField(key string, description string, match func(value any) bool) SnapshotOption {
+	return func(s *snapshot) {
+		s.masks[key] = fieldMask{description: description, match: match}
+	}
+}
+
+// IgnoreField replaces any value of the field with the given key by "<any>".
+func IgnoreField(key string) SnapshotOption {
+	return MatchField(key, "any", func(any) bool { return true })
+}
+
+// Render writes logs with log through a Logger backed by the real lgr adapter of the given format and
+// returns what was written. Every log is written with a fixed timestamp of 2022-03-05T00:00:00Z and
+// GELF messages are written with the host "lgrtest" so that the output is deterministic.
+func Render(format lgr.Format, log func(logger *lgr.Logger), opts ...SnapshotOption) ([]byte, error) {
+	s := &snapshot{
+		loggerOpts:    nil,
+		encoderConfig: lgr.EncoderConfig{}, //nolint: exhaustruct // Use the defaults of the format.
+		masks:         map[string]fieldMask{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.encoderConfig.Host == "" {
+		s.encoderConfig.Host = snapshotHost
+	}
+
+	var buf bytes.Buffer
+
+	adapter, err := lgr.NewWriterAdapter(&buf, format, s.encoderConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating %q adapter: %w", format, err)
+	}
+
+	loggerOpts := append([]lgr.Option{
+		lgr.WithTimestampFactory(func() time.Time { return snapshotTime }),
+		lgr.WithExitFunc(func(int) {}),
+	}, s.loggerOpts...)
+
+	log(lgr.FromAdapter(maskAdapter{adapter: adapter, masks: s.masks}, loggerOpts...))
+
+	return buf.Bytes(), nil
+}
+
+// AssertGolden asserts that the logs written by log, as rendered by Render, match the contents of
+// testdata/<name>.golden. The golden file is rewritten with the rendered logs when Update is set or
+// the LGRTEST_UPDATE environment variable is true.
+func AssertGolden(
+	t TestingT, //nolint: varnamelen // t is descriptive of the type.
+	name string,
+	format lgr.Format,
+	log func(logger *lgr.Logger),
+	opts ...SnapshotOption,
+) bool {
+	if h, ok := t.(interface { // If we have a testing.T and not a mocked TestingT then tell Go this is a helper func.
+		Helper()
+	}); ok {
+		h.Helper()
+	}
+
+	got, err := Render(format, log, opts...)
+	if err != nil {
+		return assert.Fail(t, "rendering logs", "%v", err)
+	}
+
+	path := filepath.Join("testdata", name+".golden")
+
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return assert.Fail(t, "creating golden file directory", "%v", err)
+		}
+
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			return assert.Fail(t, "updating golden file", "%v", err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		return assert.Fail(t, "reading golden file, run the tests with "+UpdateEnv+"=1 to create it", "%v", err)
+	}
+
+	return assert.Equal(t, string(want), string(got), "rendered logs do not match %s", path)
+}
+
+// updateGolden reports whether the golden files should be rewritten. The environment is read on each
+// call so that it can be set after the package has been initialised.
+func updateGolden() bool {
+	if Update {
+		return true
+	}
+
+	update, _ := strconv.ParseBool(os.Getenv(UpdateEnv)) // An invalid value does not update the files.
+
+	return update
+}
+
+// maskAdapter replaces the values of fields matched by the masks before passing the entry to the adapter.
+type maskAdapter struct {
+	adapter lgr.Adapter
+	masks   map[string]fieldMask
+}
+
+func (a maskAdapter) Adapt(level lgr.Level, message string, fields ...lgr.Field) {
+	a.adapter.Adapt(level, message, a.mask(fields)...)
+}
+
+func (a maskAdapter) AdaptEntry(entry lgr.Entry) {
+	entry.Fields = a.mask(entry.Fields)
+
+	if adapter, ok := a.adapter.(lgr.EntryAdapter); ok {
+		adapter.AdaptEntry(entry)
+		return
+	}
+
+	a.adapter.Adapt(entry.Level, entry.Message, entry.Fields...)
+}
+
+func (a maskAdapter) mask(fields []lgr.Field) []lgr.Field {
+	if len(a.masks) == 0 {
+		return fields
+	}
+
+	masked := make([]lgr.Field, len(fields))
+
+	for i, field := range fields {
+		masked[i] = field
+
+		if mask, ok := a.masks[field.Key]; ok && mask.match(field.Value()) {
+			masked[i] = lgr.Str(field.Key, "<"+mask.description+">")
+		}
+	}
+
+	return masked
+}
@@ -0,0 +1,197 @@
+package lgrtest_test
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+// logEveryKind writes logs at each level with bound fields, a logger name and a field of each kind.
+func logEveryKind(logger *lgr.Logger) {
+	logger = logger.Named("iam").With(lgr.Str("requestId", "abc-123"))
+
+	logger.Debug("my debug message")
+	logger.Info("my info message",
+		lgr.Bool("bool", true),
+		lgr.Duration("duration", 1500*time.Millisecond),
+		lgr.Float("float", 2.25),
+		lgr.Integer("int", -1),
+		lgr.Str("string", "some string"),
+		lgr.Time("time", time.Date(2022, time.March, 4, 12, 30, 0, 0, time.UTC)),
+		lgr.Strs("array", []string{"a", "b"}),
+		lgr.Group("group", lgr.Str("key", "value")),
+		lgr.Secret("secret", "hunter2"),
+	)
+	logger.Warn("my warn message")
+	logger.Error("my error message", lgr.Err(errors.New("some error")))
+}
+
+func TestAssertGolden(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		format lgr.Format
+	}{
+		"json":    {format: lgr.FormatJSON},
+		"console": {format: lgr.FormatConsole},
+		"logfmt":  {format: lgr.FormatLogfmt},
+		"gelf":    {format: lgr.FormatGELF},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			lgrtest.AssertGolden(t, tn, tc.format, logEveryKind)
+		})
+	}
+
+	t.Run("renders with the encoder config", func(t *testing.T) {
+		t.Parallel()
+
+		lgrtest.AssertGolden(t, "json_encoder_config", lgr.FormatJSON, func(logger *lgr.Logger) {
+			logger.Info("my info message", lgr.Duration("duration", time.Second))
+		}, lgrtest.WithEncoderConfig(lgr.EncoderConfig{
+			TimestampKey: "ts",
+			LevelKey:     "lvl",
+			MessageKey:   "msg",
+			ContextKey:   "fields",
+			TimeFormat:   time.RFC1123,
+			DurationUnit: time.Second,
+			Host:         "",
+		}))
+	})
+
+	t.Run("applies the logger options", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := lgrtest.Render(lgr.FormatLogfmt, logEveryKind, lgrtest.WithLoggerOptions(lgr.WithMinLevel(lgr.ErrorLevel)))
+		require.NoError(t, err)
+		assert.Equal(t, `time=2022-03-05T00:00:00Z level=error logger=iam msg="my error message" requestId=abc-123 error="some error"`+"\n", string(got))
+	})
+
+	t.Run("fails when the output does not match", func(t *testing.T) {
+		t.Parallel()
+		skipWhenUpdating(t)
+
+		mockT := new(bufferT)
+		assert.False(t, lgrtest.AssertGolden(mockT, "json", lgr.FormatJSON, func(logger *lgr.Logger) {
+			logger.Info("some other message")
+		}))
+		assert.Contains(t, mockT.buf.String(), "rendered logs do not match testdata/json.golden")
+	})
+
+	t.Run("fails when the golden file does not exist", func(t *testing.T) {
+		t.Parallel()
+		skipWhenUpdating(t)
+
+		mockT := new(bufferT)
+		assert.False(t, lgrtest.AssertGolden(mockT, "does_not_exist", lgr.FormatJSON, logEveryKind))
+		assert.Contains(t, mockT.buf.String(), "run the tests with LGRTEST_UPDATE=1 to create it")
+	})
+
+	t.Run("fails when the format is unknown", func(t *testing.T) {
+		t.Parallel()
+		skipWhenUpdating(t)
+
+		mockT := new(bufferT)
+		assert.False(t, lgrtest.AssertGolden(mockT, "json", "xml", logEveryKind))
+		assert.Contains(t, mockT.buf.String(), "unknown format")
+	})
+}
+
+// update rewrites the golden files in testdata, e.g. go test -update. Registering it checks that the
+// flag does not clash with one registered by lgrtest.
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	lgrtest.Update = *update
+
+	os.Exit(m.Run())
+}
+
+// TestAssertGoldenUpdate is not run in parallel as it sets the environment of the test binary.
+func TestAssertGoldenUpdate(t *testing.T) {
+	t.Setenv(lgrtest.UpdateEnv, "1")
+
+	dir := filepath.Join("testdata", "update")
+	t.Cleanup(func() { require.NoError(t, os.RemoveAll(dir)) })
+
+	assert.True(t, lgrtest.AssertGolden(t, "update/json", lgr.FormatJSON, logEveryKind))
+
+	want, err := lgrtest.Render(lgr.FormatJSON, logEveryKind)
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(dir, "json.golden"))
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+// skipWhenUpdating skips tests of failing assertions as they would rewrite the golden files.
+func skipWhenUpdating(t *testing.T) {
+	t.Helper()
+
+	if update, _ := strconv.ParseBool(os.Getenv(lgrtest.UpdateEnv)); lgrtest.Update || update {
+		t.Skip("golden files are being updated")
+	}
+}
+
+func TestMatchField(t *testing.T) {
+	t.Parallel()
+
+	isHex := func(value any) bool {
+		s, ok := value.(string)
+		return ok && s != "" && strings.Trim(s, "0123456789abcdef") == ""
+	}
+
+	testCases := map[string]struct {
+		bound  []lgr.Field
+		fields []lgr.Field
+		want   string
+	}{
+		"replaces matching values": {
+			bound:  nil,
+			fields: []lgr.Field{lgr.Str("traceId", "4bf92f3577b34da6"), lgr.Duration("elapsed", 42*time.Millisecond)},
+			want:   `time=2022-03-05T00:00:00Z level=info msg="request finished" traceId=<hex> elapsed=<any>` + "\n",
+		},
+		"renders values that do not match": {
+			bound:  nil,
+			fields: []lgr.Field{lgr.Str("traceId", "not-hex"), lgr.Duration("elapsed", time.Second)},
+			want:   `time=2022-03-05T00:00:00Z level=info msg="request finished" traceId=not-hex elapsed=<any>` + "\n",
+		},
+		"replaces bound values": {
+			bound:  []lgr.Field{lgr.Str("traceId", "4bf92f3577b34da6")},
+			fields: nil,
+			want:   `time=2022-03-05T00:00:00Z level=info msg="request finished" traceId=<hex>` + "\n",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := lgrtest.Render(lgr.FormatLogfmt, func(logger *lgr.Logger) {
+				logger.With(tc.bound...).Info("request finished", tc.fields...)
+			}, lgrtest.MatchField("traceId", "hex", isHex), lgrtest.IgnoreField("elapsed"))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
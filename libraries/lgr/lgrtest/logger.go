@@ -2,6 +2,9 @@
 package lgrtest
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/stretchr/testify/assert"
 
 	"github.com/nickbryan/collectable/libraries/lgr"
@@ -9,13 +12,25 @@ import (
 
 // New constructs a new lgr.Logger that has a test adapter for capturing all log entries
 // within the returned Entries object. This logger can be a direct replacement for an application
-// logger so logs can be asserted when writing automated tests.
-func New() (*lgr.Logger, *Entries) {
+// logger so logs can be asserted when writing automated tests. Options such as lgr.WithExitFunc can
+// be passed to test code that logs at lgr.FatalLevel without exiting the test binary.
+func New(opts ...lgr.Option) (*lgr.Logger, *Entries) {
+	adapter, e := NewAdapter()
+
+	return lgr.FromAdapter(adapter, opts...), e
+}
+
+// NewAdapter constructs a new lgr.Adapter that captures all log entries within the returned Entries
+// object. This can be used to test code that accepts an lgr.Adapter rather than an *lgr.Logger.
+func NewAdapter() (lgr.Adapter, *Entries) { //nolint: ireturn // The test adapter is an implementation detail.
 	e := &Entries{
+		mu:      sync.Mutex{},
 		entries: []Entry{},
+		resets:  0,
+		changed: make(chan struct{}),
 	}
 
-	return lgr.FromAdapter(testAdapter{entries: e}), e
+	return testAdapter{entries: e}, e
 }
 
 // Entry represents a single log entry that was created by the logger.
@@ -24,40 +39,184 @@ type Entry struct {
 	Msg string
 	// Level is the level that this log entry was written at.
 	Level lgr.Level
-	// Fields are the contextual fields that were added to this log entry.
-	Fields map[string]lgr.Field
+	// LoggerName is the name given to the logger via lgr.Logger.Named.
+	LoggerName string
+	// Fields are the contextual fields that were added to this log entry in the order that they were
+	// written, starting with the BoundFields. Fields with duplicate keys are kept.
+	Fields []lgr.Field
+	// BoundFields are the fields at the start of Fields that were bound to the logger via lgr.Logger.With
+	// rather than passed when the entry was written.
+	BoundFields []lgr.Field
+	// missing explains why the entry does not exist when it was returned for an entry that was not
+	// logged, such as by Idx for an index that is out of range, so that AssertFullEntry fails.
+	missing string
+}
+
+// Field returns the field with the given key. When more than one field has the key the last one is
+// returned as it is the one that is kept by most encoders.
+func (e Entry) Field(key string) (lgr.Field, bool) {
+	for i := len(e.Fields) - 1; i >= 0; i-- {
+		if e.Fields[i].Key == key {
+			return e.Fields[i], true
+		}
+	}
+
+	return lgr.Field{}, false //nolint: exhaustruct // The zero field is returned when the key is not found.
 }
 
-// Entries is a object that allows access to the entries that were logged via the logger.
+// Keys returns the keys of the fields in the order that they were written so that the ordering of the
+// fields can be asserted.
+func (e Entry) Keys() []string {
+	keys := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		keys[i] = field.Key
+	}
+
+	return keys
+}
+
+// DuplicateKeys returns the keys that are used by more than one field in the order that they were
+// first written.
+func (e Entry) DuplicateKeys() []string {
+	counts := make(map[string]int, len(e.Fields))
+	for _, field := range e.Fields {
+		counts[field.Key]++
+	}
+
+	var duplicates []string
+
+	for _, field := range e.Fields {
+		if counts[field.Key] > 1 {
+			duplicates = append(duplicates, field.Key)
+			counts[field.Key] = 0
+		}
+	}
+
+	return duplicates
+}
+
+// CallFields returns the fields that were passed when the entry was written, excluding the BoundFields.
+func (e Entry) CallFields() []lgr.Field {
+	return e.Fields[len(e.BoundFields):]
+}
+
+// Entries is a object that allows access to the entries that were logged via the logger. It is safe
+// to log and query entries from multiple goroutines.
 type Entries struct {
+	mu      sync.Mutex
 	entries []Entry
+	// resets counts the calls to Reset so that WaitFor knows to check the entries from the start.
+	resets uint64
+	// changed is closed and replaced each time an entry is added or the entries are reset so that
+	// WaitFor can be woken.
+	changed chan struct{}
 }
 
-// All will return all Entry objects that were logged via the logger.
+// All will return all Entry objects that were logged via the logger. The returned slice is a copy
+// and is not modified by later logs.
 func (e *Entries) All() []Entry {
-	return e.entries
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	all := make([]Entry, len(e.entries))
+	copy(all, e.entries)
+
+	return all
 }
 
-// Idx is short for entries.All()[idx].
+// Idx is short for entries.All()[idx]. When idx is out of range an empty Entry is returned that
+// always fails AssertFullEntry so that a missing log fails the assertions on the entry rather than
+// panicking. Use Len to check whether an entry exists.
 func (e *Entries) Idx(idx uint) Entry {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if idx >= uint(len(e.entries)) {
+		return missingEntry(fmt.Sprintf("no entry at index %d, %d entries were logged", idx, len(e.entries)))
+	}
+
 	return e.entries[idx]
 }
 
+// Len returns the number of entries that have been logged.
+func (e *Entries) Len() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return len(e.entries)
+}
+
+// Last returns the most recently logged entry. False is returned when nothing has been logged.
+func (e *Entries) Last() (Entry, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.entries) == 0 {
+		return missingEntry("no entries were logged"), false
+	}
+
+	return e.entries[len(e.entries)-1], true
+}
+
+// Reset removes all of the entries that have been logged so that a test can assert on only the logs
+// written after this point.
+func (e *Entries) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.entries = []Entry{}
+	e.resets++
+
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+func (e *Entries) add(entry Entry) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.entries = append(e.entries, entry)
+
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+// missingEntry returns an empty Entry that fails AssertFullEntry with the given reason.
+func missingEntry(reason string) Entry {
+	return Entry{Msg: "", Level: 0, LoggerName: "", Fields: nil, BoundFields: nil, missing: reason}
+}
+
 type testAdapter struct {
 	entries *Entries
 }
 
 func (t testAdapter) Adapt(level lgr.Level, message string, fields ...lgr.Field) {
-	mappedFields := make(map[string]lgr.Field, len(fields))
+	t.entries.add(Entry{
+		Msg:         message,
+		Level:       level,
+		LoggerName:  "",
+		Fields:      append([]lgr.Field(nil), fields...),
+		BoundFields: nil,
+		missing:     "",
+	})
+}
+
+func (t testAdapter) AdaptEntry(entry lgr.Entry) {
+	// The fields are copied as the slice may be reused by the adapter that passed the entry on.
+	fields := append([]lgr.Field(nil), entry.Fields...)
 
-	for _, f := range fields {
-		mappedFields[f.Key] = f
+	bound := entry.BoundFields
+	if bound > len(fields) {
+		bound = len(fields)
 	}
 
-	t.entries.entries = append(t.entries.entries, Entry{
-		Msg:    message,
-		Level:  level,
-		Fields: mappedFields,
+	t.entries.add(Entry{
+		Msg:         entry.Message,
+		Level:       entry.Level,
+		LoggerName:  entry.LoggerName,
+		Fields:      fields,
+		BoundFields: fields[:bound:bound],
+		missing:     "",
 	})
 }
 
@@ -69,12 +228,16 @@ type TestingT interface {
 // AssertFullEntry is a test assertion helper that wraps up common assertions for checking a full log entry.
 // In order for this assertion to pass:
 //
+//	entry must have been logged, so an entry returned by Idx for an index that is out of range fails
 //	entry.Level must match expectedLevel
 //	entry.Msg must match expectedMessage
-//	len(entry.Fields) must match len(expectedFields)
+//	len(entry.Fields) must match len(expectedFields), so duplicate keys cause the assertion to fail
 //	all expectedFields must exist in entry.Fields which is checked by Key
 //	each expectedFields Type must match the Type of the matching entry Field
 //	each expectedFields Value must match the Value of the matching entry Field
+//
+// Object, Array and Group fields are compared structurally so that the nested fields are asserted
+// using the same rules rather than by comparing the values that produced them.
 func AssertFullEntry(
 	t TestingT, //nolint: varnamelen // t is descriptive of the type.
 	entry Entry,
@@ -88,28 +251,96 @@ func AssertFullEntry(
 		h.Helper()
 	}
 
+	if entry.missing != "" {
+		assert.Fail(t, "entry does not exist", entry.missing)
+
+		return
+	}
+
 	assert.Equal(t, expectedLevel, entry.Level, "level does not match expected")
 	assert.Equal(t, expectedMessage, entry.Msg, "message does not match expected")
 	assert.Len(t, entry.Fields, len(expectedFields), "length of entry fields does not match length of expected fields")
 
 	for _, expectedField := range expectedFields {
-		if field, ok := entry.Fields[expectedField.Key]; ok {
-			assert.Equal(
-				t,
-				expectedField.Type,
-				field.Type,
-				"field.Type does not match expected for field with key %s",
-				expectedField.Key,
-			)
-			assert.Equal(
-				t,
-				expectedField.Value,
-				field.Value,
-				"field.Value does not match expected for field with key %s",
-				expectedField.Key,
-			)
+		if field, ok := entry.Field(expectedField.Key); ok {
+			assertField(t, expectedField, field, expectedField.Key)
 		} else {
 			assert.Fail(t, "field does not exist", "expectedField: %s", expectedField.Key)
 		}
 	}
 }
+
+// assertField asserts that the field matches the expected field. The path is the key of the field
+// prefixed by the keys of any parent fields so that failures within nested fields can be located.
+func assertField(
+	t TestingT, //nolint: varnamelen // t is descriptive of the type.
+	expected lgr.Field,
+	field lgr.Field,
+	path string,
+) {
+	assert.Equal(t, expected.Type, field.Type, "field.Type does not match expected for field with key %s", path)
+
+	if expected.Type != field.Type {
+		assert.Equal(t, expected.Value(), field.Value(), "field.Value does not match expected for field with key %s", path)
+
+		return
+	}
+
+	switch expected.Type { //nolint: exhaustive // All other types are compared by value.
+	case lgr.ObjectType, lgr.GroupType:
+		expectedNested, nested := nestedFields(expected), nestedFields(field)
+		mappedNested := make(map[string]lgr.Field, len(nested))
+
+		for _, f := range nested {
+			mappedNested[f.Key] = f
+		}
+
+		assert.Len(
+			t,
+			mappedNested,
+			len(expectedNested),
+			"length of fields does not match expected for field with key %s",
+			path,
+		)
+
+		for _, expectedField := range expectedNested {
+			if f, ok := mappedNested[expectedField.Key]; ok {
+				assertField(t, expectedField, f, path+"."+expectedField.Key)
+			} else {
+				assert.Fail(t, "field does not exist", "expectedField: %s.%s", path, expectedField.Key)
+			}
+		}
+	case lgr.ArrayType:
+		expectedElements, elements := nestedFields(expected), nestedFields(field)
+
+		if !assert.Len(
+			t,
+			elements,
+			len(expectedElements),
+			"length of elements does not match expected for field with key %s",
+			path,
+		) {
+			return
+		}
+
+		for i := range expectedElements {
+			assertField(t, expectedElements[i], elements[i], fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		assert.Equal(t, expected.Value(), field.Value(), "field.Value does not match expected for field with key %s", path)
+	}
+}
+
+// nestedFields returns the fields nested within an Object, Array or Group field.
+func nestedFields(field lgr.Field) []lgr.Field {
+	switch value := field.Interface.(type) {
+	case lgr.ObjectMarshaler:
+		return value.MarshalLogObject()
+	case lgr.ArrayMarshaler:
+		return value.MarshalLogArray()
+	case []lgr.Field:
+		return value
+	default:
+		return nil
+	}
+}
@@ -53,7 +53,7 @@ func TestAssertFullEntry(t *testing.T) {
 			entry: lgrtest.Entry{
 				Level:  lgr.DebugLevel,
 				Msg:    "some log message",
-				Fields: map[string]lgr.Field{"someKey": lgr.Str("someKey", "someVal")},
+				Fields: []lgr.Field{lgr.Str("someKey", "someVal")},
 			},
 			expectedLevel:  lgr.DebugLevel,
 			expectedMsg:    "some log message",
@@ -64,7 +64,7 @@ func TestAssertFullEntry(t *testing.T) {
 			entry: lgrtest.Entry{
 				Level:  lgr.DebugLevel,
 				Msg:    "some log message",
-				Fields: map[string]lgr.Field{"someKey": lgr.Str("someKey", "someVal")},
+				Fields: []lgr.Field{lgr.Str("someKey", "someVal")},
 			},
 			expectedLevel:  lgr.DebugLevel,
 			expectedMsg:    "some log message",
@@ -75,13 +75,68 @@ func TestAssertFullEntry(t *testing.T) {
 			entry: lgrtest.Entry{
 				Level:  lgr.DebugLevel,
 				Msg:    "some log message",
-				Fields: map[string]lgr.Field{"someKey": lgr.Str("someKey", "someVal")},
+				Fields: []lgr.Field{lgr.Str("someKey", "someVal")},
 			},
 			expectedLevel:  lgr.DebugLevel,
 			expectedMsg:    "some log message",
 			expectedFields: []lgr.Field{lgr.Str("someKey", "someOtherVal")},
 			wantMsg:        "field.Value does not match expected for field with key someKey",
 		},
+		"nested field does not exist": {
+			entry: lgrtest.Entry{
+				Level:  lgr.DebugLevel,
+				Msg:    "some log message",
+				Fields: []lgr.Field{lgr.Group("someKey", lgr.Str("nestedKey", "someVal"))},
+			},
+			expectedLevel:  lgr.DebugLevel,
+			expectedMsg:    "some log message",
+			expectedFields: []lgr.Field{lgr.Group("someKey", lgr.Str("otherNestedKey", "someVal"))},
+			wantMsg:        "expectedField: someKey.otherNestedKey",
+		},
+		"nested field value does not match": {
+			entry: lgrtest.Entry{
+				Level:  lgr.DebugLevel,
+				Msg:    "some log message",
+				Fields: []lgr.Field{lgr.Group("someKey", lgr.Str("nestedKey", "someVal"))},
+			},
+			expectedLevel:  lgr.DebugLevel,
+			expectedMsg:    "some log message",
+			expectedFields: []lgr.Field{lgr.Group("someKey", lgr.Str("nestedKey", "someOtherVal"))},
+			wantMsg:        "field.Value does not match expected for field with key someKey.nestedKey",
+		},
+		"nested fields len do not match": {
+			entry: lgrtest.Entry{
+				Level:  lgr.DebugLevel,
+				Msg:    "some log message",
+				Fields: []lgr.Field{lgr.Group("someKey")},
+			},
+			expectedLevel:  lgr.DebugLevel,
+			expectedMsg:    "some log message",
+			expectedFields: []lgr.Field{lgr.Group("someKey", lgr.Str("nestedKey", "someVal"))},
+			wantMsg:        "length of fields does not match expected for field with key someKey",
+		},
+		"array elements len do not match": {
+			entry: lgrtest.Entry{
+				Level:  lgr.DebugLevel,
+				Msg:    "some log message",
+				Fields: []lgr.Field{lgr.Strs("someKey", []string{"a"})},
+			},
+			expectedLevel:  lgr.DebugLevel,
+			expectedMsg:    "some log message",
+			expectedFields: []lgr.Field{lgr.Strs("someKey", []string{"a", "b"})},
+			wantMsg:        "length of elements does not match expected for field with key someKey",
+		},
+		"array element value does not match": {
+			entry: lgrtest.Entry{
+				Level:  lgr.DebugLevel,
+				Msg:    "some log message",
+				Fields: []lgr.Field{lgr.Strs("someKey", []string{"a", "b"})},
+			},
+			expectedLevel:  lgr.DebugLevel,
+			expectedMsg:    "some log message",
+			expectedFields: []lgr.Field{lgr.Strs("someKey", []string{"a", "c"})},
+			wantMsg:        "field.Value does not match expected for field with key someKey[1]",
+		},
 	}
 
 	for testName, testCase := range testCases {
@@ -96,3 +151,88 @@ func TestAssertFullEntry(t *testing.T) {
 		})
 	}
 }
+
+// stringSlice implements lgr.ArrayMarshaler with a different type to the slice constructors.
+type stringSlice []string
+
+func (s stringSlice) MarshalLogArray() []lgr.Field {
+	fields := make([]lgr.Field, len(s))
+	for i, value := range s {
+		fields[i] = lgr.Str("", value)
+	}
+
+	return fields
+}
+
+func TestAssertFullEntryComparesStructurally(t *testing.T) {
+	t.Parallel()
+
+	logger, entries := lgrtest.New()
+	logger.Info("some log message", lgr.Array("someKey", stringSlice{"a", "b"}))
+
+	mockT := new(bufferT)
+	lgrtest.AssertFullEntry(mockT, entries.Idx(0), lgr.InfoLevel, "some log message", lgr.Strs("someKey", []string{"a", "b"}))
+	assert.Empty(t, mockT.buf.String())
+}
+
+func TestEntryFields(t *testing.T) {
+	t.Parallel()
+
+	logger, entries := lgrtest.New()
+	logger.Named("iam").Named("identity").
+		With(lgr.Str("requestId", "abc-123"), lgr.Str("userId", "1")).
+		Info("some log message", lgr.Str("userId", "2"), lgr.Bool("ok", true))
+
+	entry := entries.Idx(0)
+
+	t.Run("records the logger name", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "iam.identity", entry.LoggerName)
+	})
+
+	t.Run("keeps the fields in order with duplicate keys", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"requestId", "userId", "userId", "ok"}, entry.Keys())
+		assert.Equal(t, []string{"userId"}, entry.DuplicateKeys())
+	})
+
+	t.Run("records the bound fields separately", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []lgr.Field{lgr.Str("requestId", "abc-123"), lgr.Str("userId", "1")}, entry.BoundFields)
+		assert.Equal(t, []lgr.Field{lgr.Str("userId", "2"), lgr.Bool("ok", true)}, entry.CallFields())
+	})
+
+	t.Run("returns the last field with a key", func(t *testing.T) {
+		t.Parallel()
+
+		field, ok := entry.Field("userId")
+		assert.True(t, ok)
+		assert.Equal(t, lgr.Str("userId", "2"), field)
+
+		_, ok = entry.Field("missingKey")
+		assert.False(t, ok)
+	})
+
+	t.Run("fails the full entry assertion for duplicate keys", func(t *testing.T) {
+		t.Parallel()
+
+		mockT := new(bufferT)
+		lgrtest.AssertFullEntry(mockT, entry, lgr.InfoLevel, "some log message",
+			lgr.Str("requestId", "abc-123"), lgr.Str("userId", "2"), lgr.Bool("ok", true))
+		assert.Contains(t, mockT.buf.String(), "length of entry fields does not match length of expected fields")
+	})
+
+	t.Run("records the fields of entries written without the logger", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, adapted := lgrtest.NewAdapter()
+		adapter.Adapt(lgr.WarnLevel, "some log message", lgr.Str("key", "value"), lgr.Str("key", "other value"))
+
+		assert.Equal(t, []string{"key"}, adapted.Idx(0).DuplicateKeys())
+		assert.Empty(t, adapted.Idx(0).BoundFields)
+		assert.Empty(t, adapted.Idx(0).LoggerName)
+	})
+}
@@ -0,0 +1,205 @@
+package lgrtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+// Matcher matches log entries. It has a description so that assertion failures can explain what
+// was being looked for.
+type Matcher struct {
+	description string
+	match       func(entry Entry) bool
+}
+
+// MatchFunc creates a Matcher that matches the entries for which match returns true. The description
+// is used in the messages of failed assertions.
+func MatchFunc(description string, match func(entry Entry) bool) Matcher {
+	return Matcher{description: description, match: match}
+}
+
+// ByLevel matches the entries that were logged at the given level.
+func ByLevel(level lgr.Level) Matcher {
+	return MatchFunc("level="+level.String(), func(entry Entry) bool {
+		return entry.Level == level
+	})
+}
+
+// ByMessage matches the entries that were logged with the given message.
+func ByMessage(msg string) Matcher {
+	return MatchFunc(fmt.Sprintf("msg=%q", msg), func(entry Entry) bool {
+		return entry.Msg == msg
+	})
+}
+
+// ByLoggerName matches the entries that were logged by the logger with the given name.
+func ByLoggerName(name string) Matcher {
+	return MatchFunc(fmt.Sprintf("logger=%q", name), func(entry Entry) bool {
+		return entry.LoggerName == name
+	})
+}
+
+// HasField matches the entries that have a field with the given key.
+func HasField(key string) Matcher {
+	return MatchFunc("has field "+key, func(entry Entry) bool {
+		_, ok := entry.Field(key)
+		return ok
+	})
+}
+
+// HasBoundField matches the entries that have a field with the given key that was bound to the logger
+// via lgr.Logger.With.
+func HasBoundField(key string) Matcher {
+	return MatchFunc("has bound field "+key, func(entry Entry) bool {
+		for _, field := range entry.BoundFields {
+			if field.Key == key {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// FieldMatches matches the entries that have a field with the given key for which match returns true
+// when called with the value of the field, as returned by lgr.Field.Value. It is useful for values that
+// can not be compared with assert.Equal, such as durations and errors, e.g.
+//
+//	lgrtest.FieldMatches("error", func(v any) bool { return errors.Is(v.(error), sql.ErrNoRows) })
+//
+// When more than one field has the key the last one is matched.
+func FieldMatches(key string, match func(value any) bool) Matcher {
+	return MatchFunc("field "+key+" matches", func(entry Entry) bool {
+		field, ok := entry.Field(key)
+		return ok && match(field.Value())
+	})
+}
+
+// All matches the entries that are matched by every one of the given matchers. It can be used to match
+// each step of AssertSequence on more than one property.
+func All(matchers ...Matcher) Matcher {
+	return MatchFunc(describe(matchers), func(entry Entry) bool {
+		return matchAll(entry, matchers)
+	})
+}
+
+// String returns the description of the Matcher.
+func (m Matcher) String() string {
+	return m.description
+}
+
+// Matches reports whether the entry is matched by the Matcher.
+func (m Matcher) Matches(entry Entry) bool {
+	return m.match(entry)
+}
+
+// Filter returns the entries that are matched by every one of the given matchers in the order that they
+// were logged. All entries are returned when no matchers are given.
+func (e *Entries) Filter(matchers ...Matcher) []Entry {
+	var matched []Entry
+
+	for _, entry := range e.All() {
+		if matchAll(entry, matchers) {
+			matched = append(matched, entry)
+		}
+	}
+
+	return matched
+}
+
+// WaitFor blocks until an entry that is matched by every one of the given matchers has been logged
+// and returns it. This allows a test to wait for logs written by other goroutines, such as the
+// handlers of a server. An error is returned when the context is done before a matching entry is
+// logged.
+func (e *Entries) WaitFor(ctx context.Context, matchers ...Matcher) (Entry, error) {
+	checked, checkedResets := 0, uint64(0)
+
+	for {
+		e.mu.Lock()
+		entries, resets, changed := e.entries, e.resets, e.changed
+		e.mu.Unlock()
+
+		if resets != checkedResets {
+			// The entries have been reset so start again from the beginning.
+			checked, checkedResets = 0, resets
+		}
+
+		for _, entry := range entries[checked:] {
+			if matchAll(entry, matchers) {
+				return entry, nil
+			}
+		}
+
+		checked = len(entries)
+
+		select {
+		case <-ctx.Done():
+			err := fmt.Errorf("waiting for entry matching %s: %w", describe(matchers), ctx.Err())
+
+			return missingEntry(err.Error()), err
+		case <-changed:
+		}
+	}
+}
+
+func matchAll(entry Entry, matchers []Matcher) bool {
+	for _, matcher := range matchers {
+		if !matcher.Matches(entry) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// describe joins the descriptions of the matchers so that they can be used in a message.
+func describe(matchers []Matcher) string {
+	if len(matchers) == 0 {
+		return "any entry"
+	}
+
+	descriptions := make([]string, len(matchers))
+	for i, matcher := range matchers {
+		descriptions[i] = matcher.String()
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
+// formatEntries formats each entry on its own line, prefixed by its index, so that what was logged
+// can be compared with what was expected when an assertion fails.
+func formatEntries(entries []Entry) string {
+	if len(entries) == 0 {
+		return "\t(no entries were logged)"
+	}
+
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = fmt.Sprintf("\t[%d] %s", i, formatEntry(entry))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatEntry formats the entry as its level, logger name and message followed by its fields in the
+// order that they were written.
+func formatEntry(entry Entry) string {
+	var builder strings.Builder
+
+	builder.WriteString("level=" + entry.Level.String())
+
+	if entry.LoggerName != "" {
+		builder.WriteString(fmt.Sprintf(" logger=%q", entry.LoggerName))
+	}
+
+	builder.WriteString(fmt.Sprintf(" msg=%q", entry.Msg))
+
+	for _, field := range entry.Fields {
+		builder.WriteString(fmt.Sprintf(" %s=%v", field.Key, field.Value()))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,262 @@
+package lgrtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+func TestEntriesFilter(t *testing.T) {
+	t.Parallel()
+
+	logger, entries := lgrtest.New()
+	logger.Info("request started", lgr.Str("requestId", "abc-123"))
+	logger.Error("request failed", lgr.Str("requestId", "abc-123"), lgr.Err(fmt.Errorf("querying: %w", assert.AnError)))
+	logger.Named("db").Error("request failed", lgr.Duration("elapsed", 3*time.Second))
+	logger.With(lgr.Str("requestId", "def-456")).Info("request finished")
+
+	testCases := map[string]struct {
+		matchers []lgrtest.Matcher
+		want     []string
+	}{
+		"matches every entry without matchers": {
+			matchers: nil,
+			want:     []string{"request started", "request failed", "request failed", "request finished"},
+		},
+		"matches by level": {
+			matchers: []lgrtest.Matcher{lgrtest.ByLevel(lgr.InfoLevel)},
+			want:     []string{"request started", "request finished"},
+		},
+		"matches by message": {
+			matchers: []lgrtest.Matcher{lgrtest.ByMessage("request failed")},
+			want:     []string{"request failed", "request failed"},
+		},
+		"matches by field": {
+			matchers: []lgrtest.Matcher{lgrtest.HasField("requestId")},
+			want:     []string{"request started", "request failed", "request finished"},
+		},
+		"matches by bound field": {
+			matchers: []lgrtest.Matcher{lgrtest.HasBoundField("requestId")},
+			want:     []string{"request finished"},
+		},
+		"matches by logger name": {
+			matchers: []lgrtest.Matcher{lgrtest.ByLoggerName("db")},
+			want:     []string{"request failed"},
+		},
+		"matches an error field value": {
+			matchers: []lgrtest.Matcher{lgrtest.FieldMatches("error", func(value any) bool {
+				err, ok := value.(error)
+				return ok && errors.Is(err, assert.AnError)
+			})},
+			want: []string{"request failed"},
+		},
+		"matches a duration field value": {
+			matchers: []lgrtest.Matcher{lgrtest.FieldMatches("elapsed", func(value any) bool {
+				elapsed, ok := value.(time.Duration)
+				return ok && elapsed > time.Second
+			})},
+			want: []string{"request failed"},
+		},
+		"does not match a missing field": {
+			matchers: []lgrtest.Matcher{lgrtest.FieldMatches("missingKey", func(any) bool { return true })},
+			want:     nil,
+		},
+		"matches every matcher": {
+			matchers: []lgrtest.Matcher{lgrtest.ByLevel(lgr.ErrorLevel), lgrtest.HasField("error")},
+			want:     []string{"request failed"},
+		},
+		"matches with a custom matcher": {
+			matchers: []lgrtest.Matcher{lgrtest.MatchFunc("one field", func(entry lgrtest.Entry) bool {
+				return len(entry.Fields) == 1
+			})},
+			want: []string{"request started", "request failed", "request finished"},
+		},
+		"matches nothing": {
+			matchers: []lgrtest.Matcher{lgrtest.ByLevel(lgr.WarnLevel)},
+			want:     nil,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, entry := range entries.Filter(tc.matchers...) {
+				got = append(got, entry.Msg)
+			}
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the length and last entry", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+
+		_, ok := entries.Last()
+		assert.False(t, ok)
+		assert.Equal(t, 0, entries.Len())
+
+		logger.Info("first message")
+		logger.Warn("second message")
+
+		last, ok := entries.Last()
+		assert.True(t, ok)
+		assert.Equal(t, "second message", last.Msg)
+		assert.Equal(t, 2, entries.Len())
+	})
+
+	t.Run("returns an entry that fails assertions when the index is out of range", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		logger.Info("")
+
+		entry := entries.Idx(3)
+		assert.Equal(t, lgr.InfoLevel, entry.Level)
+		assert.Empty(t, entry.Msg)
+
+		mockT := new(bufferT)
+		lgrtest.AssertFullEntry(mockT, entry, lgr.InfoLevel, "")
+		assert.Contains(t, mockT.buf.String(), "no entry at index 3, 1 entries were logged")
+
+		mockT = new(bufferT)
+		lgrtest.AssertFullEntry(mockT, entries.Idx(0), lgr.InfoLevel, "")
+		assert.Empty(t, mockT.buf.String())
+	})
+
+	t.Run("resets the entries", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+
+		logger.Info("first message")
+		entries.Reset()
+		logger.Info("second message")
+
+		assert.Equal(t, 1, entries.Len())
+		assert.Equal(t, "second message", entries.Idx(0).Msg)
+	})
+
+	t.Run("captures logs written concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		const goroutines = 50
+
+		logger, entries := lgrtest.New()
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				logger.Info("handled request", lgr.Str("requestId", strconv.Itoa(i)))
+				_ = entries.Filter(lgrtest.ByMessage("handled request"))
+				_, _ = entries.Last()
+			}(i)
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, goroutines, entries.Len())
+	})
+}
+
+func TestEntriesWaitFor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns an entry that has already been logged", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		logger.Info("my info message")
+
+		entry, err := entries.WaitFor(context.Background(), lgrtest.ByMessage("my info message"))
+		require.NoError(t, err)
+		assert.Equal(t, lgr.InfoLevel, entry.Level)
+	})
+
+	t.Run("waits for an entry logged by another goroutine", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+
+		go func() {
+			for i := 0; i < 3; i++ {
+				logger.Info("still working")
+			}
+
+			logger.Warn("done")
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		entry, err := entries.WaitFor(ctx, lgrtest.ByLevel(lgr.WarnLevel))
+		require.NoError(t, err)
+		assert.Equal(t, "done", entry.Msg)
+	})
+
+	t.Run("checks every entry logged after a reset", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		logger.Info("still working")
+		logger.Info("still working")
+
+		found := make(chan error)
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err := entries.WaitFor(ctx, lgrtest.ByMessage("done"))
+			found <- err
+		}()
+
+		// Give WaitFor time to check the entries that were logged before the reset.
+		time.Sleep(10 * time.Millisecond)
+
+		entries.Reset()
+		logger.Info("done")
+		logger.Info("still working")
+		logger.Info("still working")
+
+		require.NoError(t, <-found)
+	})
+
+	t.Run("returns an error when the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		logger.Info("my info message")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := entries.WaitFor(ctx, lgrtest.ByLevel(lgr.ErrorLevel), lgrtest.HasField("requestId"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "waiting for entry matching level=error, has field requestId")
+	})
+}
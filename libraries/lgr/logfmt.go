@@ -0,0 +1,98 @@
+package lgr
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// logfmtFieldsPrefix is added to the key of a field that has the same key as the metadata.
+const logfmtFieldsPrefix = "fields."
+
+// logfmtEncoder encodes entries as logfmt, e.g.
+//
+//	time=2022-03-05T00:00:00Z level=info logger=iam.identity msg="my info message" requestId=abc-123
+//
+// Objects and groups are flattened with their keys joined by a "." and arrays are written as a comma
+// separated list within brackets. Fields with the same key as the metadata are prefixed with "fields."
+// so that they do not overwrite it, as logfmt parsers keep the last value of a key. The keys of the
+// metadata and the format of the time can be changed through the EncoderConfig.
+type logfmtEncoder struct {
+	config EncoderConfig
+}
+
+// logfmtDefaults are the settings used by the logfmtEncoder when they are not set in its EncoderConfig.
+var logfmtDefaults = EncoderConfig{
+	TimestampKey: "time",
+	LevelKey:     "level",
+	MessageKey:   "msg",
+	ContextKey:   "",
+	TimeFormat:   time.RFC3339Nano,
+	DurationUnit: 0,
+	Host:         "",
+}
+
+// NewLogfmtAdapter creates an Adapter that writes logs to the output as logfmt. It can be combined with
+// other adapters through Tee or passed to FromAdapter.
+func NewLogfmtAdapter(output io.Writer) Adapter { //nolint: ireturn // The adapter is only used through the interface.
+	return newLineAdapter(output, nil, logfmtEncoder{config: EncoderConfig{}}) //nolint: exhaustruct // Use the defaults.
+}
+
+func (e logfmtEncoder) encode(buf *bytes.Buffer, entry Entry) {
+	config := e.config.withDefaults(logfmtDefaults)
+	kvs := make([]keyValue, 0, len(entry.Fields)+6) //nolint: gomnd // The maximum number of metadata keys.
+
+	if !entry.Time.IsZero() {
+		kvs = append(kvs, keyValue{key: config.TimestampKey, value: quoteIfNeeded(entry.Time.Format(config.TimeFormat))})
+	}
+
+	kvs = append(kvs, keyValue{key: config.LevelKey, value: entry.Level.String()})
+
+	if entry.LoggerName != "" {
+		kvs = append(kvs, keyValue{key: "logger", value: quoteIfNeeded(entry.LoggerName)})
+	}
+
+	kvs = append(kvs, keyValue{key: config.MessageKey, value: quoteIfNeeded(entry.Message)})
+
+	if entry.Caller.Defined {
+		kvs = append(kvs, keyValue{key: "caller", value: quoteIfNeeded(entry.Caller.String())})
+	}
+
+	if len(entry.Stack) > 0 {
+		kvs = append(kvs, keyValue{key: "stack", value: quoteIfNeeded(strings.Join(entry.Stack, "\n"))})
+	}
+
+	for _, kv := range flattenFields("", entry.Fields) {
+		switch kv.key {
+		case config.TimestampKey, config.LevelKey, config.MessageKey, "logger", "caller", "stack":
+			kv.key = logfmtFieldsPrefix + kv.key
+		}
+
+		kvs = append(kvs, kv)
+	}
+
+	for i, kv := range kvs {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+
+		buf.WriteString(logfmtKey(kv.key))
+		buf.WriteByte('=')
+		buf.WriteString(kv.value)
+	}
+
+	buf.WriteByte('\n')
+}
+
+// logfmtKey replaces the characters that can not appear in an unquoted logfmt key with an "_".
+func logfmtKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || r == '"' || r == '=' {
+			return '_'
+		}
+
+		return r
+	}, key)
+}
@@ -0,0 +1,116 @@
+package lgr //nolint: testpackage // Tests the unexported encoder.
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogfmtAdapter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("encodes every field type", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := FromAdapter(
+			NewLogfmtAdapter(&buf),
+			WithMinLevel(InfoLevel),
+			WithTimestampFactory(func() time.Time { return time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC) }),
+		).Named("iam").Named("identity")
+
+		logger.Debug("my debug message")
+		logger.Info("my info message", everyFieldType()...)
+		logger.Warn("my warn message")
+		logger.Error("my error message", Str("requestId", "abc-123"))
+
+		assertGolden(t, "logfmt.golden", buf.Bytes())
+	})
+
+	t.Run("writes the caller and stack", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logfmtEncoder{}.encode(&buf, Entry{
+			Level:   ErrorLevel,
+			Message: "my error message",
+			Caller:  Caller{Defined: true, PC: 1, Function: "main.main", File: "/app/main.go", Line: 42},
+			Stack:   []string{"main.main /app/main.go:42", "runtime.main /go/proc.go:250"},
+		})
+
+		assert.Equal(t,
+			`level=error msg="my error message" caller=/app/main.go:42 stack="main.main /app/main.go:42\nruntime.main /go/proc.go:250"`+"\n",
+			buf.String(),
+		)
+	})
+
+	t.Run("writes nil errors as <nil>", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logfmtEncoder{}.encode(&buf, Entry{Level: ErrorLevel, Message: "my error message", Fields: []Field{Err(nil)}})
+
+		assert.Equal(t, `level=error msg="my error message" error=<nil>`+"\n", buf.String())
+	})
+
+	t.Run("writes metadata with the keys of the encoder config", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		encoder := logfmtEncoder{config: EncoderConfig{
+			TimestampKey: "ts",
+			LevelKey:     "lvl",
+			MessageKey:   "message",
+			ContextKey:   "",
+			TimeFormat:   time.Kitchen,
+			DurationUnit: 0,
+			Host:         "",
+		}}
+		encoder.encode(&buf, Entry{
+			Time:    time.Date(2022, time.March, 5, 15, 4, 0, 0, time.UTC),
+			Level:   InfoLevel,
+			Message: "my info message",
+			Fields:  []Field{Str("requestId", "abc-123")},
+		})
+
+		assert.Equal(t, `ts=3:04PM lvl=info message="my info message" requestId=abc-123`+"\n", buf.String())
+	})
+
+	t.Run("prefixes fields with the same key as the metadata", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		encoder := logfmtEncoder{config: EncoderConfig{
+			TimestampKey: "ts",
+			LevelKey:     "",
+			MessageKey:   "",
+			ContextKey:   "",
+			TimeFormat:   time.Kitchen,
+			DurationUnit: 0,
+			Host:         "",
+		}}
+		encoder.encode(&buf, Entry{
+			Time:       time.Date(2022, time.March, 5, 15, 4, 0, 0, time.UTC),
+			Level:      InfoLevel,
+			Message:    "my info message",
+			LoggerName: "iam",
+			Fields: []Field{
+				Str("ts", "field"), Str("level", "field"), Str("msg", "field"), Str("logger", "field"),
+				Str("caller", "field"), Str("stack", "field"), Str("time", "field"),
+			},
+		})
+
+		assert.Equal(t,
+			`ts=3:04PM level=info logger=iam msg="my info message" fields.ts=field fields.level=field fields.msg=field `+
+				`fields.logger=field fields.caller=field fields.stack=field time=field`+"\n",
+			buf.String(),
+		)
+	})
+}
@@ -2,7 +2,10 @@
 package lgr
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"time"
 )
 
@@ -24,8 +27,18 @@ const (
 	// is preventing one or more functionalities from functioning properly. Logs reported at this level should
 	// be monitored and addressed with urgency.
 	ErrorLevel
+	// PanicLevel should be used to indicate that the application has reached a state that it cannot recover
+	// from within the current goroutine. The Logger panics with the message after the log has been written.
+	PanicLevel
+	// FatalLevel should be used to indicate that the application has reached a state that it cannot recover
+	// from at all. The Logger flushes any buffered logs and exits the application after the log has been written.
+	FatalLevel
 )
 
+// fatalSyncTimeout is the maximum amount of time that Logger.Fatal will wait for buffered logs to be
+// flushed before exiting.
+const fatalSyncTimeout = 5 * time.Second
+
 // Adapter is an interface to the underlying logger/log sink so that we can be vendor agnostic
 // moving forward.
 type Adapter interface {
@@ -33,6 +46,55 @@ type Adapter interface {
 	Adapt(level Level, message string, fields ...Field)
 }
 
+// Entry represents a single log entry along with any metadata that the Logger has collected
+// about it, such as the name of the Logger that wrote it.
+type Entry struct {
+	// Time is when the entry was written, as given by the TimestampFactoryFunc of the Logger. It is
+	// zero for entries created by Adapter.Adapt.
+	Time time.Time
+	// Level is the level that the entry was written at.
+	Level Level
+	// Message is the message that was written with the entry.
+	Message string
+	// LoggerName is the name given to the Logger via Logger.Named. It is empty for the root Logger.
+	LoggerName string
+	// Fields contains the fields bound to the Logger via Logger.With followed by the fields
+	// that were passed when the entry was written.
+	Fields []Field
+	// BoundFields is the number of fields at the start of Fields that were bound to the Logger via
+	// Logger.With rather than passed when the entry was written.
+	BoundFields int
+	// Caller is the location of the code that wrote the entry. It is only Defined when the Logger
+	// was created with WithCaller.
+	Caller Caller
+	// Stack is the stack trace of the goroutine that wrote the entry with each frame formatted as
+	// "function file:line". It is only set when the Logger was created with WithStackTraceAt.
+	Stack []string
+	// Context is the context.Context passed to the Ctx suffixed Logger methods so that adapters can
+	// correlate the entry with the request, such as by the active trace span. It is
+	// context.Background for entries written without a context.
+	Context context.Context
+}
+
+// EntryAdapter is an optional interface that an Adapter can implement in order to receive the
+// full Entry rather than just the level, message and fields. Adapters that do not implement
+// EntryAdapter will continue to have Adapt called for each log.
+type EntryAdapter interface {
+	Adapter
+	// AdaptEntry should immediately write the entry to the underlying logger.
+	AdaptEntry(entry Entry)
+}
+
+// Syncer is an optional interface that an Adapter can implement when it buffers logs before writing
+// them to the underlying logger/log sink. Sync is called by Logger.Sync and before Logger.Fatal exits.
+type Syncer interface {
+	// Sync should flush any buffered logs, returning early if ctx is done.
+	Sync(ctx context.Context) error
+}
+
+// ExitFunc represents a function that exits the application with the given status code.
+type ExitFunc func(code int)
+
 // TimestampFactoryFunc represents a function knows how to create time values
 // that will be used by the logger to set the timestamp field in the log context.
 type TimestampFactoryFunc func() time.Time
@@ -42,8 +104,24 @@ type TimestampFactoryFunc func() time.Time
 // to the underlying, wrapped logger, immediately.
 type Logger struct {
 	adapter          Adapter
-	minLevel         Level
+	name             string
+	fields           []Field
+	errorDetails     bool
+	addCaller        bool
+	addStack         bool
+	stackLevel       Level
+	exitFunc         ExitFunc
+	minLevel         Leveler
 	outputPath       string
+	outputs          []Output
+	format           Format
+	rotation         Rotation
+	async            *AsyncConfig
+	sampling         *SamplingConfig
+	dedupWindow      time.Duration
+	redaction        []RedactionRule
+	hooks            []Hook
+	encoderConfig    EncoderConfig
 	timestampFactory TimestampFactoryFunc
 }
 
@@ -52,15 +130,25 @@ type Logger struct {
 type Option func(l *Logger)
 
 // WithMinLevel will set the minimum level that logs will be written at. This is useful for disabling
-// DebugLevel logs when in production by setting WithMinLevel(InfoLevel) etc.
-func WithMinLevel(level Level) Option {
+// DebugLevel logs when in production by setting WithMinLevel(InfoLevel) etc. Passing a *LevelVar
+// allows the minimum level to be changed at runtime.
+func WithMinLevel(level Leveler) Option {
 	return func(l *Logger) {
 		l.minLevel = level
 	}
 }
 
+// WithExitFunc allows setting the ExitFunc that is called by Logger.Fatal after the log has been written.
+// The default is os.Exit. This is useful when testing code that calls Logger.Fatal so that the test binary
+// does not exit.
+func WithExitFunc(exitFunc ExitFunc) Option {
+	return func(l *Logger) {
+		l.exitFunc = exitFunc
+	}
+}
+
 // WithOutputPath allows setting the path that logs will be written to. This would typically
-// be set to stdout or stderr and the default is set to stderr.
+// be set to stdout or stderr and the default is set to stderr. It is ignored when WithOutputs is used.
 func WithOutputPath(outputPath string) Option {
 	return func(l *Logger) {
 		l.outputPath = outputPath
@@ -81,7 +169,7 @@ func WithTimestampFactory(factory TimestampFactoryFunc) Option {
 func New(opts ...Option) (*Logger, error) {
 	logger := FromAdapter(nil, opts...)
 
-	if err := bindZerologAdapter(logger); err != nil {
+	if err := bindOutputs(logger); err != nil {
 		return nil, fmt.Errorf("binding log adapter: %w", err)
 	}
 
@@ -100,7 +188,23 @@ func NewNop() *Logger {
 func FromAdapter(adapter Adapter, opts ...Option) *Logger {
 	logger := &Logger{
 		adapter:          nil,
+		name:             "",
+		fields:           nil,
+		errorDetails:     false,
+		addCaller:        false,
+		addStack:         false,
+		stackLevel:       ErrorLevel,
+		exitFunc:         os.Exit,
 		outputPath:       "stderr",
+		outputs:          nil,
+		format:           "",
+		rotation:         Rotation{MaxSize: 0, MaxAge: 0, MaxBackups: 0, Compress: false, ReopenOnSIGHUP: false},
+		async:            nil,
+		sampling:         nil,
+		dedupWindow:      0,
+		redaction:        nil,
+		hooks:            nil,
+		encoderConfig:    EncoderConfig{TimestampKey: "", LevelKey: "", MessageKey: "", ContextKey: "", TimeFormat: "", DurationUnit: 0, Host: ""},
 		minLevel:         DebugLevel,
 		timestampFactory: time.Now,
 	}
@@ -114,42 +218,322 @@ func FromAdapter(adapter Adapter, opts ...Option) *Logger {
 	return logger
 }
 
+// With creates a child Logger that adds the given fields to the context of every log written
+// through it, after any fields that are already bound to the parent Logger. The parent Logger
+// is not modified. Calling With on a nil Logger returns a nil Logger.
+func (l *Logger) With(fields ...Field) *Logger {
+	if l == nil {
+		return nil
+	}
+
+	child := l.clone()
+	child.fields = append(child.fields, fields...)
+
+	return child
+}
+
+// Enabled reports whether logs at the given level will be written by the Logger. It can be used to
+// skip expensive work that is only needed to build a log, see also Logger.Check. Enabled always returns
+// false for the no-op Logger.
+func (l *Logger) Enabled(level Level) bool {
+	return l != nil && l.adapter != nil && level >= l.minLevel.Level()
+}
+
+// Named creates a child Logger with the given name appended to the name of the parent Logger.
+// Names are joined with a "." so that nested components can be identified, e.g. "iam.identity".
+// Calling Named on a nil Logger returns a nil Logger.
+func (l *Logger) Named(name string) *Logger {
+	if l == nil {
+		return nil
+	}
+
+	child := l.clone()
+
+	switch {
+	case name == "":
+		// Nothing to append so the child keeps the name of the parent.
+	case child.name == "":
+		child.name = name
+	default:
+		child.name = child.name + "." + name
+	}
+
+	return child
+}
+
 // Debug will write a log at DebugLevel with the given msg and fields as context. See the Level constants
 // for information on when the level should be used.
 func (l *Logger) Debug(msg string, fields ...Field) {
-	if l == nil || l.adapter == nil {
-		return
-	}
-
-	l.adapter.Adapt(DebugLevel, msg, fields...)
+	l.log(context.Background(), DebugLevel, msg, fields)
 }
 
 // Info will write a log at InfoLevel with the given msg and fields as context. See the Level constants
 // for information on when the level should be used.
 func (l *Logger) Info(msg string, fields ...Field) {
-	if l == nil || l.adapter == nil {
-		return
-	}
-
-	l.adapter.Adapt(InfoLevel, msg, fields...)
+	l.log(context.Background(), InfoLevel, msg, fields)
 }
 
 // Warn will write a log at WarnLevel with the given msg and fields as context. See the Level constants
 // for information on when the level should be used.
 func (l *Logger) Warn(msg string, fields ...Field) {
+	l.log(context.Background(), WarnLevel, msg, fields)
+}
+
+// Error will write a log at ErrorLevel with the given msg and fields as context. See the Level constants
+// for information on when the level should be used.
+func (l *Logger) Error(msg string, fields ...Field) {
+	l.log(context.Background(), ErrorLevel, msg, fields)
+}
+
+// Panic will write a log at PanicLevel with the given msg and fields as context and then panic with
+// the msg. The panic happens even when the Logger is nil. See the Level constants for information on
+// when the level should be used.
+func (l *Logger) Panic(msg string, fields ...Field) {
+	l.log(context.Background(), PanicLevel, msg, fields)
+	panic(msg)
+}
+
+// Fatal will write a log at FatalLevel with the given msg and fields as context, flush any buffered logs
+// and then exit the application with status code 1 via the ExitFunc. The application exits even when the
+// Logger is nil. See the Level constants for information on when the level should be used.
+func (l *Logger) Fatal(msg string, fields ...Field) {
+	l.log(context.Background(), FatalLevel, msg, fields)
+	l.exit()
+}
+
+// DebugCtx will write a log at DebugLevel with the given msg and fields as context. Any fields carried by
+// ctx (see ContextFields) are added before the given fields. See the Level constants for information
+// on when the level should be used.
+func (l *Logger) DebugCtx(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, DebugLevel, msg, mergeContextFields(ctx, fields))
+}
+
+// InfoCtx will write a log at InfoLevel with the given msg and fields as context. Any fields carried by
+// ctx (see ContextFields) are added before the given fields. See the Level constants for information
+// on when the level should be used.
+func (l *Logger) InfoCtx(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, InfoLevel, msg, mergeContextFields(ctx, fields))
+}
+
+// WarnCtx will write a log at WarnLevel with the given msg and fields as context. Any fields carried by
+// ctx (see ContextFields) are added before the given fields. See the Level constants for information
+// on when the level should be used.
+func (l *Logger) WarnCtx(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, WarnLevel, msg, mergeContextFields(ctx, fields))
+}
+
+// ErrorCtx will write a log at ErrorLevel with the given msg and fields as context. Any fields carried by
+// ctx (see ContextFields) are added before the given fields. See the Level constants for information
+// on when the level should be used.
+func (l *Logger) ErrorCtx(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, ErrorLevel, msg, mergeContextFields(ctx, fields))
+}
+
+// PanicCtx will write a log at PanicLevel with the given msg and fields as context and then panic with
+// the msg. Any fields carried by ctx (see ContextFields) are added before the given fields. See the Level
+// constants for information on when the level should be used.
+func (l *Logger) PanicCtx(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, PanicLevel, msg, mergeContextFields(ctx, fields))
+	panic(msg)
+}
+
+// FatalCtx will write a log at FatalLevel with the given msg and fields as context, flush any buffered logs
+// and then exit the application with status code 1 via the ExitFunc. Any fields carried by ctx (see
+// ContextFields) are added before the given fields. See the Level constants for information on when the
+// level should be used.
+func (l *Logger) FatalCtx(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, FatalLevel, msg, mergeContextFields(ctx, fields))
+	l.exit()
+}
+
+// Sync flushes any logs that have been buffered by the Adapter. It is a no-op for adapters that do
+// not implement Syncer and for a nil Logger.
+func (l *Logger) Sync(ctx context.Context) error {
+	if l == nil || l.adapter == nil {
+		return nil
+	}
+
+	if syncer, ok := l.adapter.(Syncer); ok {
+		if err := syncer.Sync(ctx); err != nil {
+			return fmt.Errorf("syncing adapter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close flushes any buffered logs and then closes the adapter if it implements io.Closer, such as the
+// files opened by New. Logs written after Close may be lost. Calling Close on a nil Logger does nothing.
+func (l *Logger) Close() error {
 	if l == nil || l.adapter == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fatalSyncTimeout)
+	defer cancel()
+
+	if err := l.Sync(ctx); err != nil {
+		return err
+	}
+
+	if closer, ok := l.adapter.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("closing adapter: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// exit flushes any buffered logs and then calls the ExitFunc with status code 1.
+func (l *Logger) exit() {
+	if l == nil {
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fatalSyncTimeout)
+	_ = l.Sync(ctx) // We are exiting so there is nowhere left to report the error.
+
+	cancel()
+	l.exitFunc(1)
+}
+
+// newEntry creates an Entry without any of the metadata collected by the Logger.
+func newEntry(level Level, message string, fields []Field) Entry {
+	return Entry{
+		Time:        time.Time{},
+		Level:       level,
+		Message:     message,
+		LoggerName:  "",
+		Fields:      fields,
+		BoundFields: 0,
+		Caller:      Caller{Defined: false, PC: 0, Function: "", File: "", Line: 0},
+		Stack:       nil,
+		Context:     context.Background(),
+	}
+}
+
+// clone creates a shallow copy of the Logger. The capacity of the bound fields is limited so
+// that appending to the fields of the clone does not modify the fields of the original.
+func (l *Logger) clone() *Logger {
+	clone := *l
+	clone.fields = l.fields[:len(l.fields):len(l.fields)]
+
+	return &clone
+}
+
+// log writes an entry with the caller and stack trace of the code that called the public logging
+// method. It must only be called directly from those methods, or from the methods of CheckedEntry,
+// so that logCallerSkip is correct.
+func (l *Logger) log(ctx context.Context, level Level, msg string, fields []Field) {
+	if !l.Enabled(level) {
 		return
 	}
 
-	l.adapter.Adapt(WarnLevel, msg, fields...)
+	entry := newEntry(level, msg, l.withBoundFields(fields))
+	entry.Time = l.timestampFactory()
+	entry.LoggerName = l.name
+	entry.BoundFields = len(l.fields)
+	entry.Context = ctx
+
+	if l.addCaller {
+		if pcs := callers(logCallerSkip, 1); len(pcs) > 0 {
+			entry.Caller = callerFromPC(pcs[0])
+		}
+	}
+
+	if l.addStack && level >= l.stackLevel {
+		entry.Stack = formatFrames(callers(logCallerSkip, maxStackDepth))
+	}
+
+	l.write(entry)
 }
 
-// Error will write a log at ErrorLevel with the given msg and fields as context. See the Level constants
-// for information on when the level should be used.
-func (l *Logger) Error(msg string, fields ...Field) {
+// logAt writes an entry for a bridge, such as SlogHandler, where the call site is known by its
+// program counter rather than by a fixed number of frames. The stack trace starts at the call site
+// so that it does not include the frames of the bridged logger.
+func (l *Logger) logAt(ctx context.Context, pc uintptr, level Level, msg string, fields []Field) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	const skip = 4 // Skip runtime.Callers, callers, Logger.logAt and the bridge method.
+
+	entry := newEntry(level, msg, l.withBoundFields(fields))
+	entry.Time = l.timestampFactory()
+	entry.LoggerName = l.name
+	entry.BoundFields = len(l.fields)
+	entry.Context = ctx
+
+	if l.addCaller {
+		entry.Caller = callerFromPC(pc)
+	}
+
+	if l.addStack && level >= l.stackLevel {
+		entry.Stack = formatFramesFrom(callers(skip, maxStackDepth), pc)
+	}
+
+	l.write(entry)
+}
+
+// withBoundFields returns the bound fields followed by the given fields in a new slice. The given fields
+// are always copied so that they do not escape to the heap, which means that the variadic fields passed
+// to the logging methods are not allocated when the level is disabled.
+func (l *Logger) withBoundFields(fields []Field) []Field {
+	if len(l.fields) == 0 && len(fields) == 0 {
+		return nil
+	}
+
+	return append(l.fields[:len(l.fields):len(l.fields)], fields...)
+}
+
+// write calls the hooks with the entry and passes it to the Adapter.
+func (l *Logger) write(entry Entry) {
 	if l == nil || l.adapter == nil {
 		return
 	}
 
-	l.adapter.Adapt(ErrorLevel, msg, fields...)
+	if l.errorDetails {
+		entry.Fields = withErrorDetails(entry.Fields)
+	}
+
+	if len(l.redaction) > 0 {
+		entry.Message = redactMessage(l.redaction, entry.Message)
+		entry.Fields = redactFields(l.redaction, entry.Fields)
+	}
+
+	for _, hook := range l.hooks {
+		hook.OnEntry(entry)
+	}
+
+	adaptEntry(l.adapter, entry)
+}
+
+// syncAdapter syncs the adapter when it implements Syncer. It is used by adapters that wrap another Adapter.
+func syncAdapter(ctx context.Context, adapter Adapter) error {
+	if syncer, ok := adapter.(Syncer); ok {
+		return syncer.Sync(ctx) //nolint: wrapcheck // Wrapping adapters are transparent to the caller.
+	}
+
+	return nil
+}
+
+// closeAdapter closes the adapter when it implements io.Closer. It is used by adapters that wrap another Adapter.
+func closeAdapter(adapter Adapter) error {
+	if closer, ok := adapter.(io.Closer); ok {
+		return closer.Close() //nolint: wrapcheck // Wrapping adapters are transparent to the caller.
+	}
+
+	return nil
+}
+
+// adaptEntry passes the entry to AdaptEntry when the adapter implements EntryAdapter and to Adapt otherwise.
+func adaptEntry(adapter Adapter, entry Entry) {
+	if entryAdapter, ok := adapter.(EntryAdapter); ok {
+		entryAdapter.AdaptEntry(entry)
+
+		return
+	}
+
+	adapter.Adapt(entry.Level, entry.Message, entry.Fields...)
 }
@@ -1,9 +1,15 @@
 package lgr_test
 
 import (
+	"context"
 	"log"
+	"os"
 	"time"
 
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+
 	"github.com/nickbryan/collectable/libraries/lgr"
 )
 
@@ -27,3 +33,47 @@ func Example() {
 	// {"level":"warn","context":{"myFloatKey":12.3},"timestamp":"2022-03-05T00:00:00Z","message":"my warn message"}
 	// {"level":"error","context":{"myTimeKey":"2021-02-01T00:00:00Z"},"timestamp":"2022-03-05T00:00:00Z","message":"my error message"}
 }
+
+func ExampleLevelVar() {
+	levelVar := lgr.NewLevelVar(lgr.InfoLevel)
+
+	logger, err := lgr.New(
+		lgr.WithOutputPath("stdout"),
+		lgr.WithTimestampFactory(func() time.Time { return time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC) }),
+		lgr.WithMinLevel(levelVar),
+	)
+	if err != nil {
+		log.Fatalf("creating logger: %v", err)
+	}
+
+	// The level can also be changed over HTTP by mounting the LevelVar on an admin port, e.g.
+	// http.Handle("/log/level", levelVar).
+	logger.Debug("my dropped debug message")
+	levelVar.Set(lgr.DebugLevel)
+	logger.Debug("my debug message")
+
+	// Output:
+	// {"level":"debug","context":{},"timestamp":"2022-03-05T00:00:00Z","message":"my debug message"}
+}
+
+func ExampleNewOTelAdapter() {
+	// An OTLP exporter, such as otlploggrpc, can be used in place of the stdout exporter. The timestamps
+	// and resource are left out so that the output of the example does not change.
+	exporter, err := stdoutlog.New(stdoutlog.WithWriter(os.Stdout), stdoutlog.WithoutTimestamps())
+	if err != nil {
+		log.Fatalf("creating exporter: %v", err)
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)),
+		sdklog.WithResource(resource.Empty()),
+	)
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	logger := lgr.FromAdapter(lgr.NewOTelAdapter(provider), lgr.WithMinLevel(lgr.InfoLevel))
+
+	// The trace and span IDs are attached when ctx carries a span, such as one started by otelhttp.
+	logger.InfoCtx(context.Background(), "my info message", lgr.Integer("myIntKey", 123456))
+	// Output:
+	// {"Severity":9,"SeverityText":"info","Body":{"Type":"String","Value":"my info message"},"Attributes":[{"Key":"myIntKey","Value":{"Type":"Int64","Value":123456}}],"TraceID":"00000000000000000000000000000000","SpanID":"0000000000000000","TraceFlags":"00","Resource":null,"Scope":{"Name":"github.com/nickbryan/collectable/libraries/lgr","Version":"","SchemaURL":""},"DroppedAttributes":0}
+}
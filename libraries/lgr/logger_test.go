@@ -1,6 +1,7 @@
 package lgr_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -40,6 +41,189 @@ func TestLogger(t *testing.T) {
 	lgrtest.AssertFullEntry(t, logs[3], lgr.ErrorLevel, "my error message", lgr.Str("strKey", "some string"), lgr.Integer("intKey", 123))
 }
 
+func TestLoggerPanic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("panics with the message after logging", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+
+		assert.PanicsWithValue(t, "my panic message", func() {
+			logger.Panic("my panic message", lgr.Str("strKey", "some string"))
+		})
+
+		assert.Len(t, entries.All(), 1)
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.PanicLevel, "my panic message", lgr.Str("strKey", "some string"))
+	})
+
+	t.Run("panics with context fields", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		ctx := lgr.ContextFields(context.Background(), lgr.Str("ctxKey", "some context string"))
+
+		assert.PanicsWithValue(t, "my panic message", func() {
+			logger.PanicCtx(ctx, "my panic message")
+		})
+
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.PanicLevel, "my panic message", lgr.Str("ctxKey", "some context string"))
+	})
+
+	t.Run("panics when the logger is nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "my panic message", func() {
+			lgr.NewNop().Panic("my panic message")
+		})
+	})
+}
+
+// syncRecorder is an Adapter that records the order in which logs are written and synced.
+type syncRecorder struct {
+	calls []string
+}
+
+func (s *syncRecorder) Adapt(_ lgr.Level, message string, _ ...lgr.Field) {
+	s.calls = append(s.calls, "adapt: "+message)
+}
+
+func (s *syncRecorder) Sync(_ context.Context) error {
+	s.calls = append(s.calls, "sync")
+
+	return nil
+}
+
+func TestLoggerFatal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("syncs the adapter before exiting", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := &syncRecorder{calls: nil}
+
+		var code int
+
+		logger := lgr.FromAdapter(adapter, lgr.WithExitFunc(func(c int) {
+			code = c
+
+			adapter.calls = append(adapter.calls, "exit")
+		}))
+
+		logger.Fatal("my fatal message")
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, []string{"adapt: my fatal message", "sync", "exit"}, adapter.calls)
+	})
+
+	t.Run("logs with context fields", func(t *testing.T) {
+		t.Parallel()
+
+		exited := false
+		logger, entries := lgrtest.New(lgr.WithExitFunc(func(int) { exited = true }))
+		ctx := lgr.ContextFields(context.Background(), lgr.Str("ctxKey", "some context string"))
+
+		logger.FatalCtx(ctx, "my fatal message")
+
+		assert.True(t, exited)
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.FatalLevel, "my fatal message", lgr.Str("ctxKey", "some context string"))
+	})
+}
+
+func TestLoggerSync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("syncs adapters that implement Syncer", func(t *testing.T) {
+		t.Parallel()
+
+		adapter := &syncRecorder{calls: nil}
+
+		assert.NoError(t, lgr.FromAdapter(adapter).Sync(context.Background()))
+		assert.Equal(t, []string{"sync"}, adapter.calls)
+	})
+
+	t.Run("ignores adapters that do not implement Syncer", func(t *testing.T) {
+		t.Parallel()
+
+		logger, _ := lgrtest.New()
+
+		assert.NoError(t, logger.Sync(context.Background()))
+		assert.NoError(t, lgr.NewNop().Sync(context.Background()))
+	})
+}
+
+func TestLoggerWith(t *testing.T) {
+	t.Parallel()
+
+	t.Run("adds bound fields before call site fields", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+
+		logger.With(lgr.Str("requestId", "abc-123")).Info("my info message", lgr.Integer("intKey", 123))
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.InfoLevel,
+			"my info message",
+			lgr.Str("requestId", "abc-123"),
+			lgr.Integer("intKey", 123),
+		)
+	})
+
+	t.Run("accumulates fields from parent loggers", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+
+		logger.With(lgr.Str("requestId", "abc-123")).With(lgr.Str("identityId", "def-456")).Warn("my warn message")
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.WarnLevel,
+			"my warn message",
+			lgr.Str("requestId", "abc-123"),
+			lgr.Str("identityId", "def-456"),
+		)
+	})
+
+	t.Run("does not modify the parent or sibling loggers", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		parent := logger.With(lgr.Str("requestId", "abc-123"))
+
+		first := parent.With(lgr.Str("identityId", "def-456"))
+		second := parent.With(lgr.Str("identityId", "ghi-789"))
+
+		parent.Info("parent message")
+		first.Info("first message")
+		second.Info("second message")
+		logger.Info("root message")
+
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.InfoLevel, "parent message", lgr.Str("requestId", "abc-123"))
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(1),
+			lgr.InfoLevel,
+			"first message",
+			lgr.Str("requestId", "abc-123"),
+			lgr.Str("identityId", "def-456"),
+		)
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(2),
+			lgr.InfoLevel,
+			"second message",
+			lgr.Str("requestId", "abc-123"),
+			lgr.Str("identityId", "ghi-789"),
+		)
+		lgrtest.AssertFullEntry(t, entries.Idx(3), lgr.InfoLevel, "root message")
+	})
+}
+
 func TestNewNop(t *testing.T) {
 	t.Parallel()
 
@@ -50,4 +234,9 @@ func TestNewNop(t *testing.T) {
 	logger.Info("my info message", lgr.Str("strKey", "some string"))
 	logger.Warn("my warn message", lgr.Str("strKey", "some string"))
 	logger.Error("my error message", lgr.Str("strKey", "some string"))
+
+	assert.Nil(t, logger.With(lgr.Str("strKey", "some string")))
+	assert.Nil(t, logger.Named("child"))
+
+	logger.With(lgr.Str("strKey", "some string")).Named("child").Info("my info message")
 }
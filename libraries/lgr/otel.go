@@ -0,0 +1,219 @@
+package lgr
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// otelScopeName is the name of the instrumentation scope that log records are emitted with.
+const otelScopeName = "github.com/nickbryan/collectable/libraries/lgr"
+
+// Keys of the attributes that carry the metadata of an entry, following the OpenTelemetry semantic
+// conventions where one exists.
+const (
+	otelLoggerNameKey = "logger.name"
+	otelFunctionKey   = "code.function"
+	otelFilePathKey   = "code.filepath"
+	otelLineNumberKey = "code.lineno"
+	otelStackTraceKey = "code.stacktrace"
+)
+
+// otelFlusher is implemented by LoggerProviders that buffer records, such as the one from the SDK.
+type otelFlusher interface {
+	ForceFlush(ctx context.Context) error
+}
+
+// OTelAdapter is an Adapter that emits logs as OpenTelemetry log records through a LoggerProvider,
+// such as one from the OpenTelemetry SDK configured with an OTLP or stdout exporter. The context passed
+// to the Ctx suffixed Logger methods is passed on when the record is emitted so that the SDK attaches
+// the trace and span IDs of the active span.
+type OTelAdapter struct {
+	provider otellog.LoggerProvider
+	logger   otellog.Logger
+}
+
+// NewOTelAdapter creates a new OTelAdapter that emits records through the given LoggerProvider. The
+// provider is not shut down when the Logger is closed as it may be shared with other instrumentation.
+func NewOTelAdapter(provider otellog.LoggerProvider) *OTelAdapter {
+	return &OTelAdapter{provider: provider, logger: provider.Logger(otelScopeName)}
+}
+
+// Adapt emits a log record with the given level, message and fields.
+func (a *OTelAdapter) Adapt(level Level, message string, fields ...Field) {
+	a.AdaptEntry(newEntry(level, message, fields))
+}
+
+// AdaptEntry emits a log record for the entry with the fields as typed attributes.
+func (a *OTelAdapter) AdaptEntry(entry Entry) {
+	var record otellog.Record
+
+	record.SetTimestamp(entry.Time)
+	record.SetSeverity(toOTelSeverity(entry.Level))
+	record.SetSeverityText(entry.Level.String())
+	record.SetBody(otellog.StringValue(entry.Message))
+
+	if entry.LoggerName != "" {
+		record.AddAttributes(otellog.String(otelLoggerNameKey, entry.LoggerName))
+	}
+
+	if entry.Caller.Defined {
+		record.AddAttributes(
+			otellog.String(otelFunctionKey, entry.Caller.Function),
+			otellog.String(otelFilePathKey, entry.Caller.File),
+			otellog.Int(otelLineNumberKey, entry.Caller.Line),
+		)
+	}
+
+	if len(entry.Stack) > 0 {
+		values := make([]otellog.Value, len(entry.Stack))
+		for i, frame := range entry.Stack {
+			values[i] = otellog.StringValue(frame)
+		}
+
+		record.AddAttributes(otellog.Slice(otelStackTraceKey, values...))
+	}
+
+	record.AddAttributes(toOTelKeyValues(entry.Fields)...)
+
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	a.logger.Emit(ctx, record)
+}
+
+// Sync flushes the records buffered by the LoggerProvider when it supports flushing, as the provider of
+// the OpenTelemetry SDK does.
+func (a *OTelAdapter) Sync(ctx context.Context) error {
+	flusher, ok := a.provider.(otelFlusher)
+	if !ok {
+		return nil
+	}
+
+	if err := flusher.ForceFlush(ctx); err != nil {
+		return fmt.Errorf("flushing OpenTelemetry logger provider: %w", err)
+	}
+
+	return nil
+}
+
+// toOTelSeverity maps the level to the first severity number of the matching OpenTelemetry range.
+// PanicLevel and FatalLevel both map to the FATAL range with FatalLevel being the most severe.
+func toOTelSeverity(level Level) otellog.Severity {
+	switch level {
+	case DebugLevel:
+		return otellog.SeverityDebug
+	case InfoLevel:
+		return otellog.SeverityInfo
+	case WarnLevel:
+		return otellog.SeverityWarn
+	case ErrorLevel:
+		return otellog.SeverityError
+	case PanicLevel:
+		return otellog.SeverityFatal
+	case FatalLevel:
+		return otellog.SeverityFatal4
+	default:
+		return otellog.SeverityUndefined
+	}
+}
+
+func toOTelKeyValues(fields []Field) []otellog.KeyValue {
+	kvs := make([]otellog.KeyValue, len(fields))
+	for i, field := range fields {
+		kvs[i] = otellog.KeyValue{Key: field.Key, Value: toOTelValue(field)}
+	}
+
+	return kvs
+}
+
+func toOTelValue(field Field) otellog.Value { //nolint: cyclop,funlen // Easier to read whole type switch.
+	switch field.Type {
+	case ArrayType:
+		array, ok := field.Interface.(ArrayMarshaler)
+		if !ok {
+			return otellog.Value{}
+		}
+
+		elements := array.MarshalLogArray()
+		values := make([]otellog.Value, len(elements))
+
+		for i, element := range elements {
+			values[i] = toOTelValue(element)
+		}
+
+		return otellog.SliceValue(values...)
+	case BoolType:
+		return otellog.BoolValue(field.Integer == 1)
+	case ByteStringType:
+		return otellog.StringValue(string(field.Interface.([]byte))) //nolint: forcetypeassert // We know the type.
+	case DurationType:
+		return otellog.StringValue(time.Duration(field.Integer).String())
+	case ErrorType:
+		if err, ok := field.Interface.(error); ok {
+			return otellog.StringValue(err.Error())
+		}
+
+		return otellog.Value{}
+	case Float32Type:
+		return otellog.Float64Value(float64(math.Float32frombits(uint32(field.Integer))))
+	case Float64Type:
+		return otellog.Float64Value(math.Float64frombits(uint64(field.Integer)))
+	case IntType:
+		return otellog.IntValue(int(field.Integer))
+	case Int8Type:
+		return otellog.Int64Value(field.Integer)
+	case Int16Type:
+		return otellog.Int64Value(field.Integer)
+	case Int32Type:
+		return otellog.Int64Value(field.Integer)
+	case Int64Type:
+		return otellog.Int64Value(field.Integer)
+	case UintType:
+		return toOTelUint(uint64(field.Integer))
+	case Uint8Type:
+		return otellog.Int64Value(field.Integer)
+	case Uint16Type:
+		return otellog.Int64Value(field.Integer)
+	case Uint32Type:
+		return otellog.Int64Value(field.Integer)
+	case Uint64Type:
+		return toOTelUint(uint64(field.Integer))
+	case UintptrType:
+		return toOTelUint(uint64(field.Integer))
+	case StringType:
+		return otellog.StringValue(field.String)
+	case TimeType:
+		return otellog.StringValue(field.timeValue().Format(time.RFC3339Nano))
+	case ObjectType:
+		object, ok := field.Interface.(ObjectMarshaler)
+		if !ok {
+			return otellog.Value{}
+		}
+
+		return otellog.MapValue(toOTelKeyValues(object.MarshalLogObject())...)
+	case GroupType:
+		return otellog.MapValue(toOTelKeyValues(field.Interface.([]Field))...) //nolint: forcetypeassert // We know the type.
+	case SecretType:
+		return otellog.StringValue(redactedValue)
+	case UnkownType:
+		fallthrough
+	default:
+		return otellog.StringValue(fmt.Sprint(field.Interface))
+	}
+}
+
+// toOTelUint returns the value as an int64 when it fits and as a string otherwise as OpenTelemetry
+// does not support unsigned integers.
+func toOTelUint(value uint64) otellog.Value {
+	if value > math.MaxInt64 {
+		return otellog.StringValue(fmt.Sprint(value))
+	}
+
+	return otellog.Int64Value(int64(value))
+}
@@ -0,0 +1,240 @@
+package lgr_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+func TestOTelAdapter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	newLogger := func(t *testing.T, opts ...lgr.Option) (*lgr.Logger, *recordingExporter) {
+		t.Helper()
+
+		exporter := &recordingExporter{mu: sync.Mutex{}, records: nil, flushed: 0}
+		provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+		t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+		opts = append([]lgr.Option{lgr.WithTimestampFactory(func() time.Time { return now })}, opts...)
+
+		return lgr.FromAdapter(lgr.NewOTelAdapter(provider), opts...), exporter
+	}
+
+	t.Run("maps the level to the severity", func(t *testing.T) {
+		t.Parallel()
+
+		logger, exporter := newLogger(t, lgr.WithExitFunc(func(int) {}))
+
+		logger.Debug("my debug message")
+		logger.Info("my info message")
+		logger.Warn("my warn message")
+		logger.Error("my error message")
+		assert.Panics(t, func() { logger.Panic("my panic message") })
+		logger.Fatal("my fatal message")
+
+		want := []struct {
+			severity otellog.Severity
+			text     string
+			body     string
+		}{
+			{severity: otellog.SeverityDebug, text: "debug", body: "my debug message"},
+			{severity: otellog.SeverityInfo, text: "info", body: "my info message"},
+			{severity: otellog.SeverityWarn, text: "warn", body: "my warn message"},
+			{severity: otellog.SeverityError, text: "error", body: "my error message"},
+			{severity: otellog.SeverityFatal, text: "panic", body: "my panic message"},
+			{severity: otellog.SeverityFatal4, text: "fatal", body: "my fatal message"},
+		}
+
+		records := exporter.all()
+		require.Len(t, records, len(want))
+
+		for i, record := range records {
+			assert.Equal(t, want[i].severity, record.Severity())
+			assert.Equal(t, want[i].text, record.SeverityText())
+			assert.Equal(t, want[i].body, record.Body().AsString())
+			assert.Equal(t, now, record.Timestamp())
+		}
+	})
+
+	t.Run("attaches the trace and span of the context", func(t *testing.T) {
+		t.Parallel()
+
+		logger, exporter := newLogger(t)
+
+		spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+			SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			TraceFlags: trace.FlagsSampled,
+			TraceState: trace.TraceState{},
+			Remote:     false,
+		})
+		ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+		logger.InfoCtx(ctx, "my traced message")
+		logger.Info("my untraced message")
+
+		records := exporter.all()
+		require.Len(t, records, 2)
+
+		assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", records[0].TraceID().String())
+		assert.Equal(t, "0102030405060708", records[0].SpanID().String())
+		assert.Equal(t, trace.FlagsSampled, records[0].TraceFlags())
+
+		assert.False(t, records[1].TraceID().IsValid())
+		assert.False(t, records[1].SpanID().IsValid())
+	})
+
+	t.Run("writes fields as typed attributes", func(t *testing.T) {
+		t.Parallel()
+
+		logger, exporter := newLogger(t)
+
+		logger.Named("iam").With(lgr.Str("requestId", "abc-123")).Info("my info message",
+			lgr.Bool("bool", true),
+			lgr.ByteStr("byteStr", []byte("some bytes")),
+			lgr.Duration("duration", time.Second),
+			lgr.Err(errors.New("some error")),
+			lgr.Float("float", 1.5),
+			lgr.Integer("int", -1),
+			lgr.Integer("uint64", uint64(64)),
+			lgr.Integer("maxUint64", uint64(1<<64-1)),
+			lgr.Time("time", now),
+			lgr.Strs("array", []string{"a", "b"}),
+			lgr.Group("group", lgr.Str("key", "value")),
+			lgr.Secret("secret", "hunter2"),
+		)
+
+		records := exporter.all()
+		require.Len(t, records, 1)
+
+		attributes := make(map[string]otellog.Value)
+		records[0].WalkAttributes(func(kv otellog.KeyValue) bool {
+			attributes[kv.Key] = kv.Value
+
+			return true
+		})
+
+		assert.Equal(t, map[string]otellog.Value{
+			"logger.name": otellog.StringValue("iam"),
+			"requestId":   otellog.StringValue("abc-123"),
+			"bool":        otellog.BoolValue(true),
+			"byteStr":     otellog.StringValue("some bytes"),
+			"duration":    otellog.StringValue("1s"),
+			"error":       otellog.StringValue("some error"),
+			"float":       otellog.Float64Value(1.5),
+			"int":         otellog.Int64Value(-1),
+			"uint64":      otellog.Int64Value(64),
+			"maxUint64":   otellog.StringValue("18446744073709551615"),
+			"time":        otellog.StringValue("2022-03-05T00:00:00Z"),
+			"array":       otellog.SliceValue(otellog.StringValue("a"), otellog.StringValue("b")),
+			"group":       otellog.MapValue(otellog.String("key", "value")),
+			"secret":      otellog.StringValue("[REDACTED]"),
+		}, attributes)
+	})
+
+	t.Run("writes nil errors, objects and arrays as empty values", func(t *testing.T) {
+		t.Parallel()
+
+		logger, exporter := newLogger(t)
+
+		logger.Info("my info message", lgr.Err(nil), lgr.Object("object", nil), lgr.Array("array", nil))
+
+		records := exporter.all()
+		require.Len(t, records, 1)
+
+		records[0].WalkAttributes(func(kv otellog.KeyValue) bool {
+			assert.Equal(t, otellog.KindEmpty, kv.Value.Kind(), kv.Key)
+
+			return true
+		})
+	})
+
+	t.Run("writes the caller as code attributes", func(t *testing.T) {
+		t.Parallel()
+
+		logger, exporter := newLogger(t, lgr.WithCaller())
+
+		logger.Info("my info message")
+
+		records := exporter.all()
+		require.Len(t, records, 1)
+
+		attributes := make(map[string]otellog.Value)
+		records[0].WalkAttributes(func(kv otellog.KeyValue) bool {
+			attributes[kv.Key] = kv.Value
+
+			return true
+		})
+
+		assert.Contains(t, attributes["code.function"].AsString(), "TestOTelAdapter")
+		assert.Contains(t, attributes["code.filepath"].AsString(), "otel_test.go")
+		assert.Positive(t, attributes["code.lineno"].AsInt64())
+	})
+
+	t.Run("flushes the provider on sync", func(t *testing.T) {
+		t.Parallel()
+
+		logger, exporter := newLogger(t)
+
+		require.NoError(t, logger.Sync(context.Background()))
+		assert.Equal(t, 1, exporter.flushes())
+	})
+}
+
+// recordingExporter is an sdklog.Exporter that records the exported records in memory.
+type recordingExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+	flushed int
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *recordingExporter) ForceFlush(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.flushed++
+
+	return nil
+}
+
+func (e *recordingExporter) all() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]sdklog.Record(nil), e.records...)
+}
+
+func (e *recordingExporter) flushes() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.flushed
+}
@@ -0,0 +1,189 @@
+package lgr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrUnknownFormat is returned when an Output has a Format that is not supported.
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Format is the encoding that logs are written to an Output with.
+type Format string
+
+const (
+	// FormatJSON writes each log as a single line JSON object. This is the default format for outputs
+	// that are not a terminal.
+	FormatJSON Format = "json"
+	// FormatConsole writes each log in a human-readable format with the fields written as key=value. The
+	// output is colourised when it is a terminal. This is the default format for outputs that are a terminal.
+	FormatConsole Format = "console"
+	// FormatLogfmt writes each log as a single line of key=value pairs.
+	FormatLogfmt Format = "logfmt"
+	// FormatGELF sends each log as a GELF 1.1 message to a Graylog server. The Path of the output must be
+	// the address of the server in the form udp://host:port or tcp://host:port.
+	FormatGELF Format = "gelf"
+)
+
+// Output configures a destination that logs are written to by a Logger created with New.
+type Output struct {
+	// Path is the path of the file that logs are written to, or stdout or stderr. For FormatGELF it is
+	// the address of the server.
+	Path string
+	// MinLevel is the minimum level that logs will be written to the output at. It is applied after
+	// the minimum level of the Logger. Nil writes every log that the Logger writes.
+	MinLevel Leveler
+	// Format is the encoding of the logs written to the output. The default is FormatConsole when the
+	// output is a terminal and FormatJSON otherwise.
+	Format Format
+	// Rotation configures the rotation of the file at Path. It is ignored for stdout and stderr. Setting
+	// Rotation.ReopenOnSIGHUP changes how the whole process handles SIGHUP while the Logger is open.
+	Rotation Rotation
+}
+
+// WithOutputs configures the Logger created by New to write each log to every one of the given outputs,
+// each with its own minimum level and format. For example, JSON errors can be written to a file while
+// all logs are written to stderr.
+func WithOutputs(outputs ...Output) Option {
+	return func(l *Logger) {
+		l.outputs = outputs
+	}
+}
+
+// bindOutputs creates the adapter of the Logger from its outputs and wraps it with the async, dedup and
+// sampling adapters when they are configured. When no outputs have been configured a single JSON output
+// is created from the output path.
+func bindOutputs(logger *Logger) error {
+	outputs := logger.outputs
+	if len(outputs) == 0 {
+		outputs = []Output{{Path: logger.outputPath, MinLevel: nil, Format: logger.format, Rotation: logger.rotation}}
+	}
+
+	adapters := make([]Adapter, 0, len(outputs))
+
+	for i, output := range outputs {
+		adapter, err := newOutputAdapter(output, logger.encoderConfig)
+		if err != nil {
+			for _, a := range adapters {
+				_ = closeAdapter(a) // The error opening the output is more useful to the caller.
+			}
+
+			return fmt.Errorf("creating output %d (%s): %w", i, output.Path, err)
+		}
+
+		if output.MinLevel != nil {
+			adapter = levelFilterAdapter{adapter: adapter, minLevel: output.MinLevel}
+		}
+
+		adapters = append(adapters, adapter)
+	}
+
+	if len(adapters) == 1 {
+		logger.adapter = adapters[0]
+	} else {
+		logger.adapter = Tee(adapters...)
+	}
+
+	if logger.async != nil {
+		logger.adapter = NewAsyncAdapter(logger.adapter, *logger.async)
+	}
+
+	if logger.dedupWindow > 0 {
+		logger.adapter = NewDedupAdapter(logger.adapter, logger.dedupWindow)
+	}
+
+	if logger.sampling != nil {
+		logger.adapter = NewSamplingAdapter(logger.adapter, *logger.sampling)
+	}
+
+	return nil
+}
+
+// NewWriterAdapter creates an Adapter that writes logs to the output in the given format with the settings
+// of the config. It allows the encoders used by New to write to any io.Writer, such as a buffer in a test.
+// FormatGELF messages are written to the output one per line. An empty format is FormatConsole when the
+// output is a terminal and FormatJSON otherwise.
+func NewWriterAdapter(output io.Writer, format Format, config EncoderConfig) (Adapter, error) { //nolint: ireturn // The adapter is only used through the interface.
+	if err := validateFormat(format); err != nil {
+		return nil, err
+	}
+
+	return newWriterAdapter(output, nil, format, config), nil
+}
+
+// newOutputAdapter creates an Adapter that writes logs to the output in its format, configured by the
+// EncoderConfig of the Logger.
+func newOutputAdapter(output Output, config EncoderConfig) (Adapter, error) {
+	if err := validateFormat(output.Format); err != nil {
+		return nil, err
+	}
+
+	if output.Format == FormatGELF {
+		return newGELFAdapter(output.Path, config.Host)
+	}
+
+	writer, file, err := openOutputPath(output.Path, output.Rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWriterAdapter(writer, file, output.Format, config), nil
+}
+
+func validateFormat(format Format) error {
+	switch format {
+	case "", FormatJSON, FormatConsole, FormatLogfmt, FormatGELF:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// newWriterAdapter creates an Adapter that writes logs to the writer in the given format. The file should
+// be set when the writer is a file so that it can be synced and closed.
+func newWriterAdapter(writer io.Writer, file *logFile, format Format, config EncoderConfig) Adapter { //nolint: ireturn // The adapter is only used through the interface.
+	// A nil *logFile must not be stored in the closer interface as it would no longer compare to nil.
+	var closer outputCloser
+	if file != nil {
+		closer = file
+	}
+
+	terminal := isTerminal(writer)
+
+	if format == "" && terminal {
+		format = FormatConsole
+	}
+
+	switch format { //nolint: exhaustive // JSON is the default.
+	case FormatConsole:
+		_, noColor := os.LookupEnv("NO_COLOR")
+
+		return newLineAdapter(writer, closer, consoleEncoder{color: terminal && !noColor})
+	case FormatLogfmt:
+		return newLineAdapter(writer, closer, logfmtEncoder{config: config})
+	case FormatGELF:
+		return newLineAdapter(writer, closer, newGELFEncoder(config.Host, true))
+	default:
+		return newZerologAdapter(writer, file, config)
+	}
+}
+
+// openOutputPath returns the writer for the given path, opening the file with the given rotation when
+// the path is not stdout or stderr.
+func openOutputPath(path string, rotation Rotation) (io.Writer, *logFile, error) {
+	switch path {
+	case "stdout":
+		return os.Stdout, nil, nil
+	case "stderr":
+		return os.Stderr, nil, nil
+	default:
+		file, err := openLogFile(path, rotation)
+		if err != nil {
+			return nil, nil, fmt.Errorf("opening output path file: %w", err)
+		}
+
+		return file, file, nil
+	}
+}
@@ -0,0 +1,365 @@
+package lgr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+func TestWithOutputs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes to each output at its own level", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		allPath, errorPath := filepath.Join(dir, "all.log"), filepath.Join(dir, "error.log")
+
+		logger, err := lgr.New(
+			lgr.WithTimestampFactory(func() time.Time { return time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC) }),
+			lgr.WithOutputs(
+				lgr.Output{Path: allPath, MinLevel: nil, Format: lgr.FormatJSON},
+				lgr.Output{Path: errorPath, MinLevel: lgr.ErrorLevel, Format: lgr.FormatJSON},
+			),
+		)
+		require.NoError(t, err)
+
+		logger.Debug("my debug message")
+		logger.Error("my error message")
+		require.NoError(t, logger.Sync(context.Background()))
+
+		all, err := os.ReadFile(allPath)
+		require.NoError(t, err)
+		assert.Equal(t,
+			`{"level":"debug","context":{},"timestamp":"2022-03-05T00:00:00Z","message":"my debug message"}`+"\n"+
+				`{"level":"error","context":{},"timestamp":"2022-03-05T00:00:00Z","message":"my error message"}`+"\n",
+			string(all),
+		)
+
+		errs, err := os.ReadFile(errorPath)
+		require.NoError(t, err)
+		assert.Equal(t,
+			`{"level":"error","context":{},"timestamp":"2022-03-05T00:00:00Z","message":"my error message"}`+"\n",
+			string(errs),
+		)
+	})
+
+	t.Run("creates output files readable only by the owner", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "app.log")
+
+		_, err := lgr.New(lgr.WithOutputPath(path))
+		require.NoError(t, err)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("writes logfmt to a file and GELF to a server", func(t *testing.T) {
+		t.Parallel()
+
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		path := filepath.Join(t.TempDir(), "app.log")
+
+		logger, err := lgr.New(
+			lgr.WithMinLevel(lgr.InfoLevel),
+			lgr.WithTimestampFactory(func() time.Time { return time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC) }),
+			lgr.WithOutputs(
+				lgr.Output{Path: path, MinLevel: nil, Format: lgr.FormatLogfmt},
+				lgr.Output{Path: "udp://" + conn.LocalAddr().String(), MinLevel: nil, Format: lgr.FormatGELF},
+			),
+		)
+		require.NoError(t, err)
+
+		logger.Debug("my debug message")
+		logger.Info("my info message", lgr.Str("strKey", "some string"))
+		require.NoError(t, logger.Close())
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `time=2022-03-05T00:00:00Z level=info msg="my info message" strKey="some string"`+"\n", string(contents))
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+		buf := make([]byte, 1024)
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+
+		var message map[string]any
+		require.NoError(t, json.Unmarshal(buf[:n], &message))
+		assert.Equal(t, "my info message", message["short_message"])
+		assert.Equal(t, 1646438400.0, message["timestamp"])
+		assert.Equal(t, "some string", message["_strKey"])
+	})
+
+	t.Run("returns an error for a GELF output that is not a network address", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := lgr.New(lgr.WithOutputs(lgr.Output{Path: "stderr", MinLevel: nil, Format: lgr.FormatGELF}))
+		assert.ErrorIs(t, err, lgr.ErrInvalidGELFAddress)
+	})
+
+	t.Run("returns an error for an unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := lgr.New(lgr.WithOutputs(lgr.Output{Path: "stderr", MinLevel: nil, Format: "xml"}))
+		assert.ErrorIs(t, err, lgr.ErrUnknownFormat)
+	})
+
+	t.Run("returns an error when an output can not be opened", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := lgr.New(lgr.WithOutputs(
+			lgr.Output{Path: "stdout", MinLevel: nil, Format: lgr.FormatJSON},
+			lgr.Output{Path: filepath.Join(t.TempDir(), "missing", "app.log"), MinLevel: nil, Format: lgr.FormatJSON},
+		))
+		assert.ErrorContains(t, err, "creating output 1")
+	})
+}
+
+func TestLoggerClose(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := lgr.New(
+		lgr.WithOutputPath(path),
+		lgr.WithRotation(lgr.Rotation{MaxSize: 1024, MaxAge: 0, MaxBackups: 1, Compress: true, ReopenOnSIGHUP: false}),
+	)
+	require.NoError(t, err)
+
+	logger.Info("my info message")
+	require.NoError(t, logger.Close())
+	require.NoError(t, logger.Close())
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "my info message")
+	assert.NoError(t, lgr.NewNop().Close())
+}
+
+func TestWithFormat(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := lgr.New(
+		lgr.WithOutputPath(path),
+		lgr.WithFormat(lgr.FormatConsole),
+		lgr.WithTimestampFactory(func() time.Time { return time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC) }),
+	)
+	require.NoError(t, err)
+
+	logger.Info("my info message", lgr.Str("strKey", "some string"))
+	require.NoError(t, logger.Close())
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// Files are not terminals so the output is not colourised.
+	assert.Equal(t, "2022-03-05T00:00:00.000Z INF my info message                          strKey=\"some string\"\n", string(contents))
+}
+
+func TestWithEncoderConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes json with the configured keys", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "app.log")
+
+		logger, err := lgr.New(
+			lgr.WithOutputPath(path),
+			lgr.WithFormat(lgr.FormatJSON),
+			lgr.WithTimestampFactory(func() time.Time { return time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC) }),
+			lgr.WithEncoderConfig(lgr.EncoderConfig{
+				TimestampKey: "@timestamp",
+				LevelKey:     "log.level",
+				MessageKey:   "msg",
+				ContextKey:   "labels",
+				TimeFormat:   time.RFC3339Nano,
+				DurationUnit: 0,
+				Host:         "",
+			}),
+		)
+		require.NoError(t, err)
+
+		logger.Info("my info message", lgr.Duration("elapsed", time.Second))
+		require.NoError(t, logger.Close())
+
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t,
+			`{"log.level":"info","labels":{"elapsed":1000},"@timestamp":"2022-03-05T00:00:00Z","msg":"my info message"}`+"\n",
+			string(contents),
+		)
+	})
+
+	t.Run("loggers constructed in parallel do not share settings", func(t *testing.T) {
+		t.Parallel()
+
+		const loggers = 16
+
+		dir := t.TempDir()
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < loggers; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				logger, err := lgr.New(
+					lgr.WithOutputPath(filepath.Join(dir, fmt.Sprintf("%d.log", i))),
+					lgr.WithFormat(lgr.FormatJSON),
+					lgr.WithTimestampFactory(func() time.Time { return time.Date(2022, time.March, i+1, 0, 0, 0, 0, time.UTC) }),
+					lgr.WithEncoderConfig(lgr.EncoderConfig{
+						TimestampKey: fmt.Sprintf("ts%d", i),
+						LevelKey:     fmt.Sprintf("level%d", i),
+						MessageKey:   fmt.Sprintf("message%d", i),
+						ContextKey:   fmt.Sprintf("context%d", i),
+						TimeFormat:   time.DateOnly,
+						DurationUnit: time.Duration(i+1) * time.Millisecond,
+						Host:         "",
+					}),
+				)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				logger.Info("my info message", lgr.Duration("elapsed", time.Second))
+				assert.NoError(t, logger.Close())
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := 0; i < loggers; i++ {
+			contents, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%d.log", i)))
+			require.NoError(t, err)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(contents, &got))
+
+			assert.Equal(t, map[string]any{
+				fmt.Sprintf("level%d", i):   "info",
+				fmt.Sprintf("context%d", i): map[string]any{"elapsed": float64(time.Second) / float64(time.Duration(i+1)*time.Millisecond)},
+				fmt.Sprintf("ts%d", i):      fmt.Sprintf("2022-03-%02d", i+1),
+				fmt.Sprintf("message%d", i): "my info message",
+			}, got)
+		}
+	})
+}
+
+func TestNewWriterAdapter(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		format lgr.Format
+		want   string
+	}{
+		"writes json": {
+			format: lgr.FormatJSON,
+			want:   `{"level":"info","context":{"strKey":"some string"},"timestamp":"2022-03-05T00:00:00Z","message":"my info message"}` + "\n",
+		},
+		"writes json by default as a buffer is not a terminal": {
+			format: "",
+			want:   `{"level":"info","context":{"strKey":"some string"},"timestamp":"2022-03-05T00:00:00Z","message":"my info message"}` + "\n",
+		},
+		"writes console": {
+			format: lgr.FormatConsole,
+			want:   "2022-03-05T00:00:00.000Z INF my info message                          strKey=\"some string\"\n",
+		},
+		"writes logfmt": {
+			format: lgr.FormatLogfmt,
+			want:   `time=2022-03-05T00:00:00Z level=info msg="my info message" strKey="some string"` + "\n",
+		},
+		"writes gelf messages one per line": {
+			format: lgr.FormatGELF,
+			want: `{"version":"1.1","host":"test-host","short_message":"my info message","timestamp":1646438400.000,` +
+				`"level":6,"_strKey":"some string"}` + "\n",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			adapter, err := lgr.NewWriterAdapter(&buf, tc.format, lgr.EncoderConfig{
+				TimestampKey: "",
+				LevelKey:     "",
+				MessageKey:   "",
+				ContextKey:   "",
+				TimeFormat:   "",
+				DurationUnit: 0,
+				Host:         "test-host",
+			})
+			require.NoError(t, err)
+
+			logger := lgr.FromAdapter(adapter, lgr.WithTimestampFactory(func() time.Time {
+				return time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+			}))
+			logger.Info("my info message", lgr.Str("strKey", "some string"))
+
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+
+	t.Run("writes nil objects and arrays in every format", func(t *testing.T) {
+		t.Parallel()
+
+		want := map[lgr.Format]string{
+			lgr.FormatJSON:    `"context":{"objectKey":null,"arrayKey":null,"nestedKey":[null,null]}`,
+			lgr.FormatConsole: `objectKey=<nil> arrayKey=<nil> nestedKey=[<nil>,<nil>]`,
+			lgr.FormatLogfmt:  `objectKey=<nil> arrayKey=<nil> nestedKey=[<nil>,<nil>]`,
+			lgr.FormatGELF:    `"_objectKey":"\u003cnil\u003e","_arrayKey":"\u003cnil\u003e","_nestedKey":"[\u003cnil\u003e,\u003cnil\u003e]"`,
+		}
+
+		for format, wantFields := range want {
+			var buf bytes.Buffer
+
+			adapter, err := lgr.NewWriterAdapter(&buf, format, lgr.EncoderConfig{}) //nolint: exhaustruct // Use the defaults.
+			require.NoError(t, err)
+
+			// Redaction walks nested fields so it must handle nil objects and arrays too.
+			logger := lgr.FromAdapter(adapter, lgr.WithRedaction(lgr.RedactionRule{Keys: []string{"password"}})) //nolint: exhaustruct // Only keys are needed.
+			logger.Info(
+				"my info message",
+				lgr.Object("objectKey", nil),
+				lgr.Array("arrayKey", nil),
+				lgr.Array("nestedKey", chain{lgr.Object("o", nil), lgr.Array("a", nil)}),
+			)
+
+			assert.Contains(t, buf.String(), wantFields, format)
+		}
+	})
+
+	t.Run("returns an error for an unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := lgr.NewWriterAdapter(&bytes.Buffer{}, "xml", lgr.EncoderConfig{}) //nolint: exhaustruct // The config is not used.
+		assert.ErrorIs(t, err, lgr.ErrUnknownFormat)
+	})
+}
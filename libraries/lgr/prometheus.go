@@ -0,0 +1,83 @@
+package lgr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Names of the labels of the counter registered by NewPrometheusHook.
+const (
+	prometheusLevelLabel  = "level"
+	prometheusLoggerLabel = "logger"
+)
+
+// PrometheusHookConfig configures a PrometheusHook.
+type PrometheusHookConfig struct {
+	// Namespace and Subsystem are prepended to the name of the counter, log_entries_total.
+	Namespace string
+	Subsystem string
+	// LoggerName adds a "logger" label with the name of the Logger that wrote the entry. Only enable
+	// this when logger names are bounded as each name creates a new time series.
+	LoggerName bool
+}
+
+// PrometheusHook is a Hook that counts the entries written by a Logger by level and, optionally, by
+// logger name so that alerts can be raised on the rate of errors, e.g.
+//
+//	sum(rate(log_entries_total{level="error"}[5m])) > 1
+type PrometheusHook struct {
+	counter    *prometheus.CounterVec
+	loggerName bool
+}
+
+// NewPrometheusHook creates a PrometheusHook and registers its counter with the given Registerer. When
+// an identical counter has already been registered, such as by another Logger, it is shared.
+func NewPrometheusHook(registerer prometheus.Registerer, config PrometheusHookConfig) (*PrometheusHook, error) {
+	labels := []string{prometheusLevelLabel}
+	if config.LoggerName {
+		labels = append(labels, prometheusLoggerLabel)
+	}
+
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{ //nolint: exhaustruct // Only the name and help are required.
+		Namespace: config.Namespace,
+		Subsystem: config.Subsystem,
+		Name:      "log_entries_total",
+		Help:      "The number of log entries written by level.",
+	}, labels)
+
+	if err := registerer.Register(counter); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &registered) {
+			return nil, fmt.Errorf("registering log entries counter: %w", err)
+		}
+
+		existing, ok := registered.ExistingCollector.(*prometheus.CounterVec)
+		if !ok {
+			return nil, fmt.Errorf("registering log entries counter: %w", err)
+		}
+
+		counter = existing
+	}
+
+	if !config.LoggerName {
+		// Initialise the count of each level so that rates can be calculated from the first entry.
+		for _, level := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, PanicLevel, FatalLevel} {
+			counter.WithLabelValues(level.String())
+		}
+	}
+
+	return &PrometheusHook{counter: counter, loggerName: config.LoggerName}, nil
+}
+
+// OnEntry increments the count of entries for the level and logger name of the entry.
+func (h *PrometheusHook) OnEntry(entry Entry) {
+	if h.loggerName {
+		h.counter.WithLabelValues(entry.Level.String(), entry.LoggerName).Inc()
+
+		return
+	}
+
+	h.counter.WithLabelValues(entry.Level.String()).Inc()
+}
@@ -0,0 +1,104 @@
+package lgr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+)
+
+func TestPrometheusHook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts entries by level", func(t *testing.T) {
+		t.Parallel()
+
+		registry := prometheus.NewRegistry()
+
+		hook, err := lgr.NewPrometheusHook(registry, lgr.PrometheusHookConfig{Namespace: "iam", Subsystem: "", LoggerName: false})
+		require.NoError(t, err)
+
+		logger := lgr.FromAdapter(&entryRecorder{}, lgr.WithHook(hook), lgr.WithMinLevel(lgr.InfoLevel))
+		logger.Debug("my debug message")
+		logger.Info("my info message")
+		logger.Error("my error message")
+		logger.Named("identity").Error("my error message")
+
+		assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP iam_log_entries_total The number of log entries written by level.
+# TYPE iam_log_entries_total counter
+iam_log_entries_total{level="debug"} 0
+iam_log_entries_total{level="error"} 2
+iam_log_entries_total{level="fatal"} 0
+iam_log_entries_total{level="info"} 1
+iam_log_entries_total{level="panic"} 0
+iam_log_entries_total{level="warn"} 0
+`)))
+	})
+
+	t.Run("counts entries by logger name", func(t *testing.T) {
+		t.Parallel()
+
+		registry := prometheus.NewRegistry()
+
+		hook, err := lgr.NewPrometheusHook(registry, lgr.PrometheusHookConfig{Namespace: "", Subsystem: "", LoggerName: true})
+		require.NoError(t, err)
+
+		logger := lgr.FromAdapter(&entryRecorder{}, lgr.WithHook(hook))
+		logger.Error("my error message")
+		logger.Named("iam").Error("my error message")
+		logger.Named("iam").Warn("my warn message")
+
+		assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP log_entries_total The number of log entries written by level.
+# TYPE log_entries_total counter
+log_entries_total{level="error",logger=""} 1
+log_entries_total{level="error",logger="iam"} 1
+log_entries_total{level="warn",logger="iam"} 1
+`)))
+	})
+
+	t.Run("shares the counter between hooks", func(t *testing.T) {
+		t.Parallel()
+
+		registry := prometheus.NewRegistry()
+		config := lgr.PrometheusHookConfig{Namespace: "", Subsystem: "", LoggerName: false}
+
+		first, err := lgr.NewPrometheusHook(registry, config)
+		require.NoError(t, err)
+
+		second, err := lgr.NewPrometheusHook(registry, config)
+		require.NoError(t, err)
+
+		lgr.FromAdapter(&entryRecorder{}, lgr.WithHook(first)).Error("my error message")
+		lgr.FromAdapter(&entryRecorder{}, lgr.WithHook(second)).Error("my error message")
+
+		assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP log_entries_total The number of log entries written by level.
+# TYPE log_entries_total counter
+log_entries_total{level="debug"} 0
+log_entries_total{level="error"} 2
+log_entries_total{level="fatal"} 0
+log_entries_total{level="info"} 0
+log_entries_total{level="panic"} 0
+log_entries_total{level="warn"} 0
+`)))
+	})
+
+	t.Run("returns an error when the counter conflicts with another", func(t *testing.T) {
+		t.Parallel()
+
+		registry := prometheus.NewRegistry()
+
+		_, err := lgr.NewPrometheusHook(registry, lgr.PrometheusHookConfig{Namespace: "", Subsystem: "", LoggerName: false})
+		require.NoError(t, err)
+
+		_, err = lgr.NewPrometheusHook(registry, lgr.PrometheusHookConfig{Namespace: "", Subsystem: "", LoggerName: true})
+		assert.Error(t, err)
+	})
+}
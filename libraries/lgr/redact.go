@@ -0,0 +1,223 @@
+package lgr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// redactedValue replaces values that have been masked and the values of Secret fields.
+const redactedValue = "[REDACTED]"
+
+// hashPrefix identifies values that have been replaced by their hash.
+const hashPrefix = "sha256:"
+
+var (
+	// EmailPattern matches email addresses.
+	EmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// BearerTokenPattern matches bearer tokens as they appear in an Authorization header.
+	BearerTokenPattern = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`)
+	// JWTPattern matches JSON Web Tokens.
+	JWTPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+)
+
+// RedactionMode determines how a RedactionRule redacts the values that it matches.
+type RedactionMode int
+
+const (
+	// RedactMask replaces the value with "[REDACTED]". This is the default mode.
+	RedactMask RedactionMode = iota
+	// RedactHash replaces the value with its SHA-256 hash so that values can still be correlated
+	// across logs without being written in clear text.
+	RedactHash
+	// RedactDrop removes the field from the entry.
+	RedactDrop
+)
+
+// RedactionRule describes which fields are redacted and how. A field is redacted when its key matches
+// one of the Keys or KeyPatterns. The string values of fields that are not matched by key are redacted
+// where they match one of the ValuePatterns, with only the matching parts of the value being replaced
+// when masking or hashing. Groups, objects and arrays are redacted recursively. The ValuePatterns are
+// also applied to the message of the entry, which is masked rather than dropped in RedactDrop mode.
+type RedactionRule struct {
+	// Keys are the field keys that are redacted, compared case-insensitively.
+	Keys []string
+	// KeyPatterns are glob patterns, as supported by path.Match, that are matched against the field
+	// keys case-insensitively. Malformed patterns never match.
+	KeyPatterns []string
+	// ValuePatterns are matched against the string values of fields.
+	ValuePatterns []*regexp.Regexp
+	// Mode determines how the matched values are redacted.
+	Mode RedactionMode
+}
+
+// WithRedaction configures the Logger to redact fields according to the given rules before they are
+// passed to the Adapter. Rules are applied in order with the first rule that matches the key of a field
+// taking effect.
+func WithRedaction(rules ...RedactionRule) Option {
+	return func(l *Logger) {
+		l.redaction = append(l.redaction[:len(l.redaction):len(l.redaction)], rules...)
+	}
+}
+
+// secret is the value of a Secret field. It implements the interfaces used to format and encode values
+// so that it is redacted even when an adapter falls back to reflection.
+type secret string
+
+// String returns the redacted value.
+func (secret) String() string {
+	return redactedValue
+}
+
+// GoString returns the redacted value.
+func (secret) GoString() string {
+	return redactedValue
+}
+
+// MarshalText returns the redacted value.
+func (secret) MarshalText() ([]byte, error) {
+	return []byte(redactedValue), nil
+}
+
+// LogValue returns the redacted value.
+func (secret) LogValue() slog.Value {
+	return slog.StringValue(redactedValue)
+}
+
+// redactMessage applies the value patterns of the rules to the message.
+func redactMessage(rules []RedactionRule, message string) string {
+	for _, rule := range rules {
+		for _, pattern := range rule.ValuePatterns {
+			if rule.Mode == RedactHash {
+				message = pattern.ReplaceAllStringFunc(message, hashValue)
+			} else {
+				message = pattern.ReplaceAllLiteralString(message, redactedValue)
+			}
+		}
+	}
+
+	return message
+}
+
+// redactFields applies the rules to each of the fields. The given slice is not modified as it may be
+// owned by the caller.
+func redactFields(rules []RedactionRule, fields []Field) []Field {
+	redacted := make([]Field, 0, len(fields))
+
+	for _, field := range fields {
+		if field, ok := redactField(rules, field); ok {
+			redacted = append(redacted, field)
+		}
+	}
+
+	return redacted
+}
+
+// redactField applies the rules to the field, returning false if the field should be dropped.
+func redactField(rules []RedactionRule, field Field) (Field, bool) {
+	for _, rule := range rules {
+		if rule.matchesKey(field.Key) {
+			return rule.redactValue(field)
+		}
+	}
+
+	switch field.Type { //nolint: exhaustive // Only nested and string types can contain values to redact.
+	case GroupType:
+		group := field.Interface.([]Field) //nolint: forcetypeassert // We know the type.
+		return Group(field.Key, redactFields(rules, group)...), true
+	case ObjectType:
+		object, ok := field.Interface.(ObjectMarshaler)
+		if !ok {
+			return field, true
+		}
+
+		return Group(field.Key, redactFields(rules, object.MarshalLogObject())...), true
+	case ArrayType:
+		array, ok := field.Interface.(ArrayMarshaler)
+		if !ok {
+			return field, true
+		}
+
+		return Array(field.Key, fieldArray(redactFields(rules, array.MarshalLogArray()))), true
+	case StringType, ByteStringType, ErrorType:
+		return redactString(rules, field)
+	default:
+		return field, true
+	}
+}
+
+// redactString applies the value patterns of the rules to a field that carries a string, returning
+// false if the field should be dropped. The field is only replaced when one of the patterns matches.
+func redactString(rules []RedactionRule, field Field) (Field, bool) {
+	original := fmt.Sprint(fieldToValue(field))
+	value := original
+
+	for _, rule := range rules {
+		for _, pattern := range rule.ValuePatterns {
+			if !pattern.MatchString(value) {
+				continue
+			}
+
+			switch rule.Mode {
+			case RedactDrop:
+				return field, false
+			case RedactHash:
+				value = pattern.ReplaceAllStringFunc(value, hashValue)
+			case RedactMask:
+				fallthrough
+			default:
+				value = pattern.ReplaceAllLiteralString(value, redactedValue)
+			}
+		}
+	}
+
+	if value == original {
+		return field, true
+	}
+
+	return Str(field.Key, value), true
+}
+
+func (r RedactionRule) matchesKey(key string) bool {
+	for _, k := range r.Keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+
+	for _, pattern := range r.KeyPatterns {
+		if matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(key)); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactValue redacts the whole value of a field that was matched by its key.
+func (r RedactionRule) redactValue(field Field) (Field, bool) {
+	switch r.Mode {
+	case RedactDrop:
+		return field, false
+	case RedactHash:
+		if field.Type == SecretType {
+			return Str(field.Key, redactedValue), true
+		}
+
+		return Str(field.Key, hashValue(fmt.Sprint(fieldToValue(field)))), true
+	case RedactMask:
+		fallthrough
+	default:
+		return Str(field.Key, redactedValue), true
+	}
+}
+
+func hashValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,109 @@
+package lgr
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultSamplingInterval is the interval of a SamplingAdapter when no interval is configured.
+const defaultSamplingInterval = time.Second
+
+// SamplingConfig configures a SamplingAdapter. Entries are counted per level and message within each
+// Interval. The First entries are written and after that every Thereafter entry is written. When both
+// First and Thereafter are zero, or negative, every entry is dropped.
+type SamplingConfig struct {
+	// Interval is the period after which the counts are reset. The default, used when Interval is zero
+	// or negative, is one second.
+	Interval time.Duration
+	// First is the number of entries with the same level and message that are written each interval.
+	First int
+	// Thereafter is the rate at which entries are written once First has been reached, e.g. 100 writes
+	// every 100th entry. Zero drops every entry once First has been reached.
+	Thereafter int
+}
+
+// WithSampling configures the Logger created by New to sample its logs. See SamplingAdapter.
+func WithSampling(config SamplingConfig) Option {
+	return func(l *Logger) {
+		l.sampling = &config
+	}
+}
+
+// samplingKey identifies the entries that are counted together by a SamplingAdapter.
+type samplingKey struct {
+	level   Level
+	message string
+}
+
+// SamplingAdapter is an Adapter that limits the number of entries written for high volume logs by
+// sampling entries with the same level and message. This caps the cost of logging on hot paths while
+// still giving an indication of what is happening.
+type SamplingAdapter struct {
+	adapter Adapter
+	config  SamplingConfig
+	now     func() time.Time
+
+	mu         sync.Mutex
+	counts     map[samplingKey]int
+	intervalAt time.Time
+}
+
+// NewSamplingAdapter creates a new SamplingAdapter that writes the sampled entries to the given Adapter.
+func NewSamplingAdapter(adapter Adapter, config SamplingConfig) *SamplingAdapter {
+	if config.Interval <= 0 {
+		config.Interval = defaultSamplingInterval
+	}
+
+	return &SamplingAdapter{
+		adapter:    adapter,
+		config:     config,
+		now:        time.Now,
+		mu:         sync.Mutex{},
+		counts:     make(map[samplingKey]int),
+		intervalAt: time.Time{},
+	}
+}
+
+// Adapt writes the log to the underlying Adapter if it is sampled.
+func (s *SamplingAdapter) Adapt(level Level, message string, fields ...Field) {
+	s.AdaptEntry(newEntry(level, message, fields))
+}
+
+// AdaptEntry writes the entry to the underlying Adapter if it is sampled.
+func (s *SamplingAdapter) AdaptEntry(entry Entry) {
+	if s.sample(samplingKey{level: entry.Level, message: entry.Message}) {
+		adaptEntry(s.adapter, entry)
+	}
+}
+
+// Sync syncs the underlying Adapter if it implements Syncer.
+func (s *SamplingAdapter) Sync(ctx context.Context) error {
+	return syncAdapter(ctx, s.adapter)
+}
+
+// Close closes the underlying Adapter if it implements io.Closer.
+func (s *SamplingAdapter) Close() error {
+	return closeAdapter(s.adapter)
+}
+
+// sample counts the entry and reports whether it should be written.
+func (s *SamplingAdapter) sample(key samplingKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// All of the counts are reset together so that only the keys seen this interval are held in memory.
+	if now := s.now(); now.Sub(s.intervalAt) >= s.config.Interval {
+		s.counts = make(map[samplingKey]int)
+		s.intervalAt = now
+	}
+
+	s.counts[key]++
+	count := s.counts[key]
+
+	if count <= s.config.First {
+		return true
+	}
+
+	return s.config.Thereafter > 0 && (count-s.config.First)%s.config.Thereafter == 0
+}
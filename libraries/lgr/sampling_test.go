@@ -0,0 +1,101 @@
+package lgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// messageRecorder is an Adapter that records the message of each log.
+type messageRecorder struct {
+	messages []string
+}
+
+func (r *messageRecorder) Adapt(_ Level, message string, _ ...Field) {
+	r.messages = append(r.messages, message)
+}
+
+func TestSamplingAdapter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes the first entries and then every nth entry per level and message", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &messageRecorder{messages: nil}
+		sampler := NewSamplingAdapter(recorder, SamplingConfig{Interval: time.Hour, First: 2, Thereafter: 3})
+
+		for i := 0; i < 8; i++ {
+			sampler.Adapt(InfoLevel, "info")
+			sampler.Adapt(ErrorLevel, "info")
+		}
+
+		sampler.Adapt(InfoLevel, "other")
+
+		// Entries 1, 2, 5 and 8 are written for each level.
+		assert.Equal(t, []string{"info", "info", "info", "info", "info", "info", "info", "info", "other"}, recorder.messages)
+	})
+
+	t.Run("drops every entry after the first when thereafter is zero", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &messageRecorder{messages: nil}
+		sampler := NewSamplingAdapter(recorder, SamplingConfig{Interval: time.Hour, First: 1, Thereafter: 0})
+
+		for i := 0; i < 5; i++ {
+			sampler.Adapt(InfoLevel, "info")
+		}
+
+		assert.Equal(t, []string{"info"}, recorder.messages)
+	})
+
+	t.Run("resets the counts each interval", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+		recorder := &messageRecorder{messages: nil}
+		sampler := NewSamplingAdapter(recorder, SamplingConfig{Interval: time.Second, First: 1, Thereafter: 0})
+		sampler.now = func() time.Time { return now }
+
+		sampler.Adapt(InfoLevel, "first")
+		sampler.Adapt(InfoLevel, "first")
+
+		now = now.Add(time.Second)
+
+		sampler.Adapt(InfoLevel, "first")
+		sampler.Adapt(InfoLevel, "first")
+
+		assert.Equal(t, []string{"first", "first"}, recorder.messages)
+	})
+
+	t.Run("resets the counts each second without an interval", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+		recorder := &messageRecorder{messages: nil}
+		sampler := NewSamplingAdapter(recorder, SamplingConfig{Interval: 0, First: 1, Thereafter: 0})
+		sampler.now = func() time.Time { return now }
+
+		sampler.Adapt(InfoLevel, "first")
+		sampler.Adapt(InfoLevel, "first")
+
+		now = now.Add(time.Second - time.Nanosecond)
+		sampler.Adapt(InfoLevel, "first")
+
+		now = now.Add(time.Nanosecond)
+		sampler.Adapt(InfoLevel, "first")
+
+		assert.Equal(t, []string{"first", "first"}, recorder.messages)
+	})
+
+	t.Run("drops every entry when first and thereafter are zero", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &messageRecorder{messages: nil}
+		sampler := NewSamplingAdapter(recorder, SamplingConfig{Interval: time.Hour, First: 0, Thereafter: 0})
+
+		sampler.Adapt(InfoLevel, "info")
+
+		assert.Empty(t, recorder.messages)
+	})
+}
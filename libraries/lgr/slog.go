@@ -0,0 +1,325 @@
+package lgr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// SlogHandler is a slog.Handler that writes records to an Adapter so that code and libraries that
+// log through log/slog share the same output as the Logger. Slog groups are added to the log context
+// as Group fields.
+type SlogHandler struct {
+	logger *Logger
+	groups []slogGroup
+}
+
+// slogGroup is a group opened via SlogHandler.WithGroup along with the attrs that were added to it.
+type slogGroup struct {
+	name   string
+	fields []Field
+}
+
+// NewSlogHandler creates a new SlogHandler that writes records to the given Adapter with the passed
+// options applied. The handler can be passed to slog.New to create a *slog.Logger.
+func NewSlogHandler(adapter Adapter, opts ...Option) *SlogHandler {
+	return &SlogHandler{
+		logger: FromAdapter(adapter, opts...),
+		groups: nil,
+	}
+}
+
+// Enabled reports whether the handler writes records at the given level.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Enabled(fromSlogLevel(level))
+}
+
+// Handle writes the record to the Adapter.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make([]Field, 0, record.NumAttrs())
+
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, attr)
+
+		return true
+	})
+
+	// Nest the fields within the open groups from the innermost group outwards. Groups without
+	// any fields are omitted as required by slog.Handler.
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		group := h.groups[i]
+
+		if nested := append(group.fields[:len(group.fields):len(group.fields)], fields...); len(nested) > 0 {
+			fields = []Field{Group(group.name, nested...)}
+		}
+	}
+
+	h.logger.logAt(ctx, record.PC, fromSlogLevel(record.Level), record.Message, mergeContextFields(ctx, fields))
+
+	return nil
+}
+
+// WithAttrs returns a new SlogHandler that adds the given attrs to every record it writes.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler { //nolint: ireturn // Required by slog.Handler.
+	fields := make([]Field, 0, len(attrs))
+
+	for _, attr := range attrs {
+		fields = appendSlogAttr(fields, attr)
+	}
+
+	if len(h.groups) == 0 {
+		return &SlogHandler{
+			logger: h.logger.With(fields...),
+			groups: nil,
+		}
+	}
+
+	groups := make([]slogGroup, len(h.groups))
+	copy(groups, h.groups)
+
+	last := &groups[len(groups)-1]
+	last.fields = append(last.fields[:len(last.fields):len(last.fields)], fields...)
+
+	return &SlogHandler{
+		logger: h.logger,
+		groups: groups,
+	}
+}
+
+// WithGroup returns a new SlogHandler that nests all subsequent attrs within a group with the given name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler { //nolint: ireturn // Required by slog.Handler.
+	if name == "" {
+		return h
+	}
+
+	return &SlogHandler{
+		logger: h.logger,
+		groups: append(h.groups[:len(h.groups):len(h.groups)], slogGroup{name: name, fields: nil}),
+	}
+}
+
+func appendSlogAttr(fields []Field, attr slog.Attr) []Field {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() != slog.KindGroup {
+		return append(fields, fromSlogValue(attr.Key, attr.Value))
+	}
+
+	// Groups with an empty key are inlined and groups without any fields are omitted.
+	if attr.Key == "" {
+		for _, groupAttr := range attr.Value.Group() {
+			fields = appendSlogAttr(fields, groupAttr)
+		}
+
+		return fields
+	}
+
+	var group []Field
+	for _, groupAttr := range attr.Value.Group() {
+		group = appendSlogAttr(group, groupAttr)
+	}
+
+	if len(group) == 0 {
+		return fields
+	}
+
+	return append(fields, Group(attr.Key, group...))
+}
+
+func fromSlogValue(key string, value slog.Value) Field {
+	switch value.Kind() {
+	case slog.KindBool:
+		return Bool(key, value.Bool())
+	case slog.KindDuration:
+		return Duration(key, value.Duration())
+	case slog.KindFloat64:
+		return Float(key, value.Float64())
+	case slog.KindInt64:
+		return Integer(key, value.Int64())
+	case slog.KindString:
+		return Str(key, value.String())
+	case slog.KindTime:
+		return Time(key, value.Time())
+	case slog.KindUint64:
+		return Integer(key, value.Uint64())
+	case slog.KindAny, slog.KindGroup, slog.KindLogValuer:
+		fallthrough
+	default:
+		switch v := value.Any().(type) {
+		case error:
+			return NamedErr(key, v)
+		case []byte:
+			return ByteStr(key, v)
+		default:
+			return Field{Type: UnkownType, Key: key, Integer: 0, String: "", Interface: v}
+		}
+	}
+}
+
+func fromSlogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < slog.LevelWarn:
+		return InfoLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	case level < slog.LevelError+slogLevelStep:
+		return ErrorLevel
+	case level < slog.LevelError+2*slogLevelStep:
+		return PanicLevel
+	default:
+		return FatalLevel
+	}
+}
+
+// SlogAdapter is an Adapter that writes logs to a slog.Handler. Fields are nested under a "context"
+// group so that the output matches the shape of the logs written by the default Logger.
+type SlogAdapter struct {
+	handler slog.Handler
+}
+
+// NewSlogAdapter creates a new SlogAdapter that writes logs to the given slog.Handler. The returned
+// adapter can be passed to FromAdapter to create a Logger.
+func NewSlogAdapter(handler slog.Handler) *SlogAdapter {
+	return &SlogAdapter{handler: handler}
+}
+
+// Adapt writes a log to the underlying slog.Handler.
+func (s *SlogAdapter) Adapt(level Level, message string, fields ...Field) {
+	s.AdaptEntry(newEntry(level, message, fields))
+}
+
+// AdaptEntry writes the entry to the underlying slog.Handler. The name of the Logger that wrote
+// the entry is added to the record with the key "logger" and the stack trace with the key "stack".
+// The caller is set as the PC of the record so that it is written by handlers with AddSource set.
+// The context of the entry is passed to the handler so that handlers can read values from it, such as
+// the trace and span set by the ctx-aware methods of the Logger.
+func (s *SlogAdapter) AdaptEntry(entry Entry) {
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	level := toSlogLevel(entry.Level)
+
+	if !s.handler.Enabled(ctx, level) {
+		return
+	}
+
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	record := slog.NewRecord(timestamp, level, entry.Message, entry.Caller.PC)
+
+	if entry.LoggerName != "" {
+		record.AddAttrs(slog.String("logger", entry.LoggerName))
+	}
+
+	record.AddAttrs(slog.Attr{Key: "context", Value: slog.GroupValue(toSlogAttrs(entry.Fields)...)})
+
+	if len(entry.Stack) > 0 {
+		record.AddAttrs(slog.Any("stack", entry.Stack))
+	}
+
+	_ = s.handler.Handle(ctx, record) // There is nothing we can do with the error as Adapt does not return.
+}
+
+func toSlogAttrs(fields []Field) []slog.Attr {
+	attrs := make([]slog.Attr, len(fields))
+	for i, field := range fields {
+		attrs[i] = toSlogAttr(field)
+	}
+
+	return attrs
+}
+
+func toSlogAttr(field Field) slog.Attr { //nolint: cyclop,funlen // Easier to read whole type switch.
+	switch field.Type {
+	case BoolType:
+		return slog.Bool(field.Key, field.Integer == 1)
+	case ByteStringType:
+		return slog.String(field.Key, string(field.Interface.([]byte))) //nolint: forcetypeassert // We know the type.
+	case DurationType:
+		return slog.Duration(field.Key, time.Duration(field.Integer))
+	case Float32Type:
+		return slog.Float64(field.Key, float64(math.Float32frombits(uint32(field.Integer))))
+	case Float64Type:
+		return slog.Float64(field.Key, math.Float64frombits(uint64(field.Integer)))
+	case IntType:
+		return slog.Int(field.Key, int(field.Integer))
+	case Int8Type:
+		return slog.Int64(field.Key, field.Integer)
+	case Int16Type:
+		return slog.Int64(field.Key, field.Integer)
+	case Int32Type:
+		return slog.Int64(field.Key, field.Integer)
+	case Int64Type:
+		return slog.Int64(field.Key, field.Integer)
+	case UintType:
+		return slog.Uint64(field.Key, uint64(field.Integer))
+	case Uint8Type:
+		return slog.Uint64(field.Key, uint64(field.Integer))
+	case Uint16Type:
+		return slog.Uint64(field.Key, uint64(field.Integer))
+	case Uint32Type:
+		return slog.Uint64(field.Key, uint64(field.Integer))
+	case Uint64Type:
+		return slog.Uint64(field.Key, uint64(field.Integer))
+	case UintptrType:
+		return slog.Uint64(field.Key, uint64(field.Integer))
+	case StringType:
+		return slog.String(field.Key, field.String)
+	case TimeType:
+		return slog.Time(field.Key, field.timeValue())
+	case ObjectType:
+		object, ok := field.Interface.(ObjectMarshaler)
+		if !ok {
+			return slog.Any(field.Key, nil)
+		}
+
+		return slog.Attr{Key: field.Key, Value: slog.GroupValue(toSlogAttrs(object.MarshalLogObject())...)}
+	case ArrayType:
+		// Slog does not have an array kind so the elements are written via reflection.
+		return slog.Any(field.Key, fieldToValue(field))
+	case GroupType:
+		group := field.Interface.([]Field) //nolint: forcetypeassert // We know the type.
+		return slog.Attr{Key: field.Key, Value: slog.GroupValue(toSlogAttrs(group)...)}
+	case SecretType:
+		return slog.String(field.Key, redactedValue)
+	case ErrorType, UnkownType:
+		fallthrough
+	default:
+		return slog.Any(field.Key, field.Interface)
+	}
+}
+
+// slogLevelStep is the gap between the levels defined by slog, which PanicLevel and FatalLevel continue.
+const slogLevelStep = slog.LevelError - slog.LevelWarn
+
+func toSlogLevel(level Level) slog.Level {
+	switch level {
+	case DebugLevel:
+		return slog.LevelDebug
+	case InfoLevel:
+		return slog.LevelInfo
+	case WarnLevel:
+		return slog.LevelWarn
+	case ErrorLevel:
+		return slog.LevelError
+	case PanicLevel:
+		return slog.LevelError + slogLevelStep
+	case FatalLevel:
+		return slog.LevelError + 2*slogLevelStep
+	default:
+		panic(fmt.Sprintf("log level unexpected: %d", level))
+	}
+}
@@ -0,0 +1,300 @@
+package lgr_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+func TestSlogHandler(t *testing.T) {
+	t.Parallel()
+
+	// Used to assert time for time fields. Slog strips the monotonic clock reading from time values.
+	now := time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("maps slog levels", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		slogger := slog.New(lgr.NewSlogHandler(adapter))
+
+		slogger.Debug("my debug message")
+		slogger.Info("my info message")
+		slogger.Warn("my warn message")
+		slogger.Error("my error message")
+		slogger.Log(context.Background(), slog.LevelError+2, "my custom level message")
+		slogger.Log(context.Background(), slog.LevelError+4, "my panic message")
+		slogger.Log(context.Background(), slog.LevelError+8, "my fatal message")
+
+		logs := entries.All()
+		assert.Len(t, logs, 7)
+
+		lgrtest.AssertFullEntry(t, logs[0], lgr.DebugLevel, "my debug message")
+		lgrtest.AssertFullEntry(t, logs[1], lgr.InfoLevel, "my info message")
+		lgrtest.AssertFullEntry(t, logs[2], lgr.WarnLevel, "my warn message")
+		lgrtest.AssertFullEntry(t, logs[3], lgr.ErrorLevel, "my error message")
+		lgrtest.AssertFullEntry(t, logs[4], lgr.ErrorLevel, "my custom level message")
+		lgrtest.AssertFullEntry(t, logs[5], lgr.PanicLevel, "my panic message")
+		lgrtest.AssertFullEntry(t, logs[6], lgr.FatalLevel, "my fatal message")
+	})
+
+	t.Run("respects the minimum level", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		slogger := slog.New(lgr.NewSlogHandler(adapter, lgr.WithMinLevel(lgr.WarnLevel)))
+
+		slogger.Info("my info message")
+		slogger.Warn("my warn message")
+
+		assert.Len(t, entries.All(), 1)
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.WarnLevel, "my warn message")
+	})
+
+	t.Run("converts attrs", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		err := errors.New("some error string")
+
+		slog.New(lgr.NewSlogHandler(adapter)).Info(
+			"my info message",
+			slog.Bool("boolKey", true),
+			slog.Duration("durationKey", time.Second),
+			slog.Float64("floatKey", 1.5),
+			slog.Int("intKey", 1),
+			slog.String("strKey", "some string"),
+			slog.Time("timeKey", now),
+			slog.Uint64("uintKey", 2),
+			slog.Any("errKey", err),
+			slog.Any("bytesKey", []byte("some byte string")),
+			slog.Any("anyKey", []int{1, 2}),
+			slog.Attr{},
+		)
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.InfoLevel,
+			"my info message",
+			lgr.Bool("boolKey", true),
+			lgr.Duration("durationKey", time.Second),
+			lgr.Float("floatKey", 1.5),
+			lgr.Integer("intKey", int64(1)),
+			lgr.Str("strKey", "some string"),
+			lgr.Time("timeKey", now),
+			lgr.Integer("uintKey", uint64(2)),
+			lgr.NamedErr("errKey", err),
+			lgr.ByteStr("bytesKey", []byte("some byte string")),
+			lgr.Field{Type: lgr.UnkownType, Key: "anyKey", Integer: 0, String: "", Interface: []int{1, 2}},
+		)
+	})
+
+	t.Run("nests grouped attrs", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+
+		slog.New(lgr.NewSlogHandler(adapter)).
+			With(slog.String("requestId", "abc-123")).
+			WithGroup("http").
+			With(slog.String("method", "GET")).
+			WithGroup("").
+			Info(
+				"my info message",
+				slog.Group("response", slog.Int("status", 200), slog.Group("", slog.String("inline", "value"))),
+				slog.Group("empty"),
+			)
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.InfoLevel,
+			"my info message",
+			lgr.Str("requestId", "abc-123"),
+			lgr.Group(
+				"http",
+				lgr.Str("method", "GET"),
+				lgr.Group("response", lgr.Integer("status", int64(200)), lgr.Str("inline", "value")),
+			),
+		)
+	})
+
+	t.Run("omits empty groups", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+
+		slog.New(lgr.NewSlogHandler(adapter)).WithGroup("http").WithGroup("response").Info("my info message")
+
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.InfoLevel, "my info message")
+	})
+
+	t.Run("adds context fields", func(t *testing.T) {
+		t.Parallel()
+
+		adapter, entries := lgrtest.NewAdapter()
+		ctx := lgr.ContextFields(context.Background(), lgr.Str("requestId", "abc-123"))
+
+		slog.New(lgr.NewSlogHandler(adapter)).InfoContext(ctx, "my info message")
+
+		lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.InfoLevel, "my info message", lgr.Str("requestId", "abc-123"))
+	})
+
+	t.Run("is nil safe", func(t *testing.T) {
+		t.Parallel()
+
+		handler := lgr.NewSlogHandler(nil)
+
+		assert.False(t, handler.Enabled(context.Background(), slog.LevelError))
+		assert.NoError(t, handler.Handle(context.Background(), slog.NewRecord(now, slog.LevelError, "", 0)))
+	})
+}
+
+func TestSlogAdapter(t *testing.T) {
+	t.Parallel()
+
+	removeTime := func(groups []string, attr slog.Attr) slog.Attr {
+		if len(groups) == 0 && attr.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+
+		return attr
+	}
+
+	testCases := map[string]struct {
+		log  func(logger *lgr.Logger)
+		want string
+	}{
+		"logs debug message": {
+			log:  func(logger *lgr.Logger) { logger.Debug("my debug message") },
+			want: `{"level":"DEBUG","msg":"my debug message"}`,
+		},
+		"logs info message": {
+			log:  func(logger *lgr.Logger) { logger.Info("my info message") },
+			want: `{"level":"INFO","msg":"my info message"}`,
+		},
+		"logs warn message": {
+			log:  func(logger *lgr.Logger) { logger.Warn("my warn message") },
+			want: `{"level":"WARN","msg":"my warn message"}`,
+		},
+		"logs error message": {
+			log:  func(logger *lgr.Logger) { logger.Error("my error message") },
+			want: `{"level":"ERROR","msg":"my error message"}`,
+		},
+		"sets logger name": {
+			log:  func(logger *lgr.Logger) { logger.Named("iam").Info("my info message") },
+			want: `{"level":"INFO","msg":"my info message","logger":"iam"}`,
+		},
+		"sets nil objects and arrays": {
+			log: func(logger *lgr.Logger) {
+				logger.Info("my info message", lgr.Object("objectKey", nil), lgr.Array("arrayKey", nil))
+			},
+			want: `{"level":"INFO","msg":"my info message","context":{"objectKey":null,"arrayKey":null}}`,
+		},
+		"sets fields": {
+			log: func(logger *lgr.Logger) {
+				logger.With(lgr.Str("requestId", "abc-123")).Info(
+					"my info message",
+					lgr.Bool("boolKey", true),
+					lgr.ByteStr("byteStrKey", []byte("some byte string")),
+					lgr.Duration("durationKey", time.Second),
+					lgr.NamedErr("errKey", errors.New("some error string")),
+					lgr.Float("float32Key", float32(1.5)),
+					lgr.Float("float64Key", float64(2.5)),
+					lgr.Integer("intKey", 1),
+					lgr.Integer("int8Key", int8(2)),
+					lgr.Integer("int16Key", int16(3)),
+					lgr.Integer("int32Key", int32(4)),
+					lgr.Integer("int64Key", int64(5)),
+					lgr.Integer("uintKey", uint(6)),
+					lgr.Integer("uint8Key", uint8(7)),
+					lgr.Integer("uint16Key", uint16(8)),
+					lgr.Integer("uint32Key", uint32(9)),
+					lgr.Integer("uint64Key", uint64(10)),
+					lgr.Integer("uintptrKey", uintptr(11)),
+					lgr.Time("timeKey", time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)),
+					lgr.Field{Type: lgr.UnkownType, Key: "unknownKey", Integer: 0, String: "", Interface: []int{1, 2}},
+					lgr.Object("objectKey", testObject{name: "some name"}),
+					lgr.Strs("arrayKey", []string{"a", "b"}),
+					lgr.Group("groupKey", lgr.Str("nestedKey", "some nested string")),
+				)
+			},
+			want: `{"level":"INFO","msg":"my info message","context":{"requestId":"abc-123","boolKey":true,` +
+				`"byteStrKey":"some byte string","durationKey":1000000000,"errKey":"some error string",` +
+				`"float32Key":1.5,"float64Key":2.5,"intKey":1,"int8Key":2,"int16Key":3,"int32Key":4,"int64Key":5,` +
+				`"uintKey":6,"uint8Key":7,"uint16Key":8,"uint32Key":9,"uint64Key":10,"uintptrKey":11,` +
+				`"timeKey":"2021-02-01T00:00:00Z","unknownKey":[1,2],"objectKey":{"name":"some name"},` +
+				`"arrayKey":["a","b"],"groupKey":{"nestedKey":"some nested string"}}}`,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+
+			handler := slog.NewJSONHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: removeTime})
+			tc.log(lgr.FromAdapter(lgr.NewSlogAdapter(handler)))
+			assert.Equal(t, tc.want+"\n", buffer.String())
+		})
+	}
+
+	t.Run("respects the handler level", func(t *testing.T) {
+		t.Parallel()
+
+		var buffer bytes.Buffer
+
+		handler := slog.NewJSONHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelWarn, ReplaceAttr: removeTime})
+		logger := lgr.FromAdapter(lgr.NewSlogAdapter(handler))
+
+		logger.Info("my info message")
+		logger.Warn("my warn message")
+
+		assert.Equal(t, `{"level":"WARN","msg":"my warn message"}`+"\n", buffer.String())
+	})
+
+	t.Run("passes the context of the entry to the handler", func(t *testing.T) {
+		t.Parallel()
+
+		handler := &contextRecorder{Handler: slog.NewJSONHandler(&bytes.Buffer{}, nil), enabled: nil, handled: nil}
+		ctx := context.WithValue(context.Background(), contextRecorderKey{}, "some value")
+
+		lgr.FromAdapter(lgr.NewSlogAdapter(handler)).InfoCtx(ctx, "my info message")
+
+		assert.Equal(t, []context.Context{ctx}, handler.enabled)
+		assert.Equal(t, []context.Context{ctx}, handler.handled)
+	})
+}
+
+type contextRecorderKey struct{}
+
+// contextRecorder is a slog.Handler that records the context passed to Enabled and Handle.
+type contextRecorder struct {
+	slog.Handler
+	enabled []context.Context
+	handled []context.Context
+}
+
+func (h *contextRecorder) Enabled(ctx context.Context, level slog.Level) bool {
+	h.enabled = append(h.enabled, ctx)
+
+	return h.Handler.Enabled(ctx, level)
+}
+
+func (h *contextRecorder) Handle(ctx context.Context, record slog.Record) error {
+	h.handled = append(h.handled, ctx)
+
+	return h.Handler.Handle(ctx, record) //nolint: wrapcheck // The error is returned as is from the handler.
+}
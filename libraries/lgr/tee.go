@@ -0,0 +1,91 @@
+package lgr
+
+import (
+	"context"
+	"errors"
+)
+
+// TeeAdapter is an Adapter that writes each log to many adapters so that a single Logger call fans
+// out consistently to every sink.
+type TeeAdapter struct {
+	adapters []Adapter
+}
+
+// Tee creates a new TeeAdapter that writes logs to each of the given adapters in order. Nil adapters
+// are ignored.
+func Tee(adapters ...Adapter) *TeeAdapter {
+	tee := &TeeAdapter{adapters: make([]Adapter, 0, len(adapters))}
+
+	for _, adapter := range adapters {
+		if adapter != nil {
+			tee.adapters = append(tee.adapters, adapter)
+		}
+	}
+
+	return tee
+}
+
+// Adapt writes the log to each of the adapters.
+func (t *TeeAdapter) Adapt(level Level, message string, fields ...Field) {
+	t.AdaptEntry(newEntry(level, message, fields))
+}
+
+// AdaptEntry writes the entry to each of the adapters. Adapters that do not implement EntryAdapter
+// have Adapt called instead.
+func (t *TeeAdapter) AdaptEntry(entry Entry) {
+	for _, adapter := range t.adapters {
+		adaptEntry(adapter, entry)
+	}
+}
+
+// Sync flushes each of the adapters that implement Syncer. All adapters are synced even when one
+// of them fails and the errors are joined.
+func (t *TeeAdapter) Sync(ctx context.Context) error {
+	var errs []error
+
+	for _, adapter := range t.adapters {
+		if err := syncAdapter(ctx, adapter); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes each of the adapters that implement io.Closer. All adapters are closed even when one
+// of them fails and the errors are joined.
+func (t *TeeAdapter) Close() error {
+	var errs []error
+
+	for _, adapter := range t.adapters {
+		if err := closeAdapter(adapter); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// levelFilterAdapter is an Adapter that only writes logs at or above a minimum level.
+type levelFilterAdapter struct {
+	adapter  Adapter
+	minLevel Leveler
+}
+
+func (f levelFilterAdapter) Adapt(level Level, message string, fields ...Field) {
+	f.AdaptEntry(newEntry(level, message, fields))
+}
+
+func (f levelFilterAdapter) AdaptEntry(entry Entry) {
+	if entry.Level >= f.minLevel.Level() {
+		adaptEntry(f.adapter, entry)
+	}
+}
+
+func (f levelFilterAdapter) Sync(ctx context.Context) error {
+	return syncAdapter(ctx, f.adapter)
+}
+
+func (f levelFilterAdapter) Close() error {
+	return closeAdapter(f.adapter)
+}
@@ -0,0 +1,67 @@
+package lgr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+// failingSyncer is an Adapter that fails to sync.
+type failingSyncer struct {
+	err error
+}
+
+func (failingSyncer) Adapt(lgr.Level, string, ...lgr.Field) {}
+
+func (f failingSyncer) Sync(_ context.Context) error {
+	return f.err
+}
+
+func TestTee(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes each log to every adapter", func(t *testing.T) {
+		t.Parallel()
+
+		first, firstEntries := lgrtest.NewAdapter()
+		second, secondEntries := lgrtest.NewAdapter()
+		logger := lgr.FromAdapter(lgr.Tee(first, nil, second)).With(lgr.Str("boundKey", "some bound string"))
+
+		logger.Info("my info message", lgr.Integer("intKey", 123))
+
+		for _, entries := range []*lgrtest.Entries{firstEntries, secondEntries} {
+			assert.Len(t, entries.All(), 1)
+			lgrtest.AssertFullEntry(t, entries.Idx(0), lgr.InfoLevel, "my info message",
+				lgr.Str("boundKey", "some bound string"), lgr.Integer("intKey", 123))
+		}
+	})
+
+	t.Run("passes the entry to entry adapters", func(t *testing.T) {
+		t.Parallel()
+
+		recorder := &entryRecorder{}
+		lgr.FromAdapter(lgr.Tee(recorder), lgr.WithCaller()).Named("tee").Info("my info message")
+
+		assert.Len(t, recorder.entries, 1)
+		assert.Equal(t, "tee", recorder.entries[0].LoggerName)
+		assert.True(t, recorder.entries[0].Caller.Defined)
+	})
+
+	t.Run("syncs every adapter and joins the errors", func(t *testing.T) {
+		t.Parallel()
+
+		errFirst, errSecond := errors.New("first"), errors.New("second")
+		recorder := &syncRecorder{calls: nil}
+
+		err := lgr.Tee(failingSyncer{err: errFirst}, recorder, failingSyncer{err: errSecond}).Sync(context.Background())
+
+		assert.ErrorIs(t, err, errFirst)
+		assert.ErrorIs(t, err, errSecond)
+		assert.Equal(t, []string{"sync"}, recorder.calls)
+	})
+}
@@ -0,0 +1,407 @@
+package lgr
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ZapAdapter is an Adapter that writes logs to a zap.Logger. Fields are nested under a "context"
+// object so that the output matches the shape of the logs written by the default Logger.
+type ZapAdapter struct {
+	logger *zap.Logger
+}
+
+// NewZapAdapter creates a new ZapAdapter that writes logs to the given zap.Logger. The returned
+// adapter can be passed to FromAdapter to create a Logger.
+func NewZapAdapter(logger *zap.Logger) *ZapAdapter {
+	return &ZapAdapter{logger: logger}
+}
+
+// Adapt writes a log to the underlying zap.Logger.
+func (z *ZapAdapter) Adapt(level Level, message string, fields ...Field) {
+	z.AdaptEntry(newEntry(level, message, fields))
+}
+
+// AdaptEntry writes the entry to the underlying zap.Logger. The name of the Logger that wrote the
+// entry is appended to the name of the zap.Logger and the caller and stack trace, when collected by
+// the Logger, replace those collected by the zap.Logger.
+func (z *ZapAdapter) AdaptEntry(entry Entry) {
+	level := toZapLevel(entry.Level)
+
+	// The zap.Logger panics or exits after writing PanicLevel and FatalLevel entries but that is the
+	// responsibility of the Logger. Those entries are checked at ErrorLevel and written at their level.
+	checked := z.logger.Check(min(level, zapcore.ErrorLevel), entry.Message)
+	if checked == nil {
+		return
+	}
+
+	checked.Level = level
+
+	if !entry.Time.IsZero() {
+		checked.Time = entry.Time
+	}
+
+	switch {
+	case entry.LoggerName == "":
+		// Nothing to append so the entry keeps the name of the zap.Logger.
+	case checked.LoggerName == "":
+		checked.LoggerName = entry.LoggerName
+	default:
+		checked.LoggerName = checked.LoggerName + "." + entry.LoggerName
+	}
+
+	if entry.Caller.Defined {
+		checked.Caller = zapcore.EntryCaller{
+			Defined:  true,
+			PC:       entry.Caller.PC,
+			File:     entry.Caller.File,
+			Line:     entry.Caller.Line,
+			Function: entry.Caller.Function,
+		}
+	}
+
+	if len(entry.Stack) > 0 {
+		checked.Stack = strings.Join(entry.Stack, "\n")
+	}
+
+	checked.Write(zap.Object("context", zapFields(entry.Fields)))
+}
+
+// Sync flushes any logs buffered by the underlying zap.Logger.
+func (z *ZapAdapter) Sync(_ context.Context) error {
+	if err := z.logger.Sync(); err != nil {
+		return fmt.Errorf("syncing zap logger: %w", err)
+	}
+
+	return nil
+}
+
+// zapFields implements zapcore.ObjectMarshaler so that the fields can be nested under a single key.
+type zapFields []Field
+
+func (fields zapFields) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, field := range fields {
+		toZapField(field).AddTo(enc)
+	}
+
+	return nil
+}
+
+// zapArray implements zapcore.ArrayMarshaler so that the fields can be written as the elements of an array.
+type zapArray []Field
+
+// nolint: cyclop,funlen,gocognit // Easier to read whole type switch.
+func (fields zapArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, field := range fields {
+		switch field.Type {
+		case BoolType:
+			enc.AppendBool(field.Integer == 1)
+		case ByteStringType:
+			enc.AppendByteString(field.Interface.([]byte)) //nolint: forcetypeassert // We know the type.
+		case DurationType:
+			enc.AppendDuration(time.Duration(field.Integer))
+		case ErrorType:
+			if err, ok := field.Interface.(error); ok {
+				enc.AppendString(err.Error())
+			} else {
+				_ = enc.AppendReflected(nil)
+			}
+		case Float32Type:
+			enc.AppendFloat32(math.Float32frombits(uint32(field.Integer)))
+		case Float64Type:
+			enc.AppendFloat64(math.Float64frombits(uint64(field.Integer)))
+		case IntType:
+			enc.AppendInt(int(field.Integer))
+		case Int8Type:
+			enc.AppendInt8(int8(field.Integer))
+		case Int16Type:
+			enc.AppendInt16(int16(field.Integer))
+		case Int32Type:
+			enc.AppendInt32(int32(field.Integer))
+		case Int64Type:
+			enc.AppendInt64(field.Integer)
+		case UintType:
+			enc.AppendUint(uint(field.Integer))
+		case Uint8Type:
+			enc.AppendUint8(uint8(field.Integer))
+		case Uint16Type:
+			enc.AppendUint16(uint16(field.Integer))
+		case Uint32Type:
+			enc.AppendUint32(uint32(field.Integer))
+		case Uint64Type:
+			enc.AppendUint64(uint64(field.Integer))
+		case UintptrType:
+			enc.AppendUintptr(uintptr(field.Integer))
+		case StringType:
+			enc.AppendString(field.String)
+		case TimeType:
+			enc.AppendTime(field.timeValue())
+		case ObjectType:
+			object, ok := field.Interface.(ObjectMarshaler)
+			if !ok {
+				if err := enc.AppendReflected(nil); err != nil {
+					return fmt.Errorf("appending object: %w", err)
+				}
+
+				continue
+			}
+
+			if err := enc.AppendObject(zapFields(object.MarshalLogObject())); err != nil {
+				return fmt.Errorf("appending object: %w", err)
+			}
+		case ArrayType:
+			array, ok := field.Interface.(ArrayMarshaler)
+			if !ok {
+				if err := enc.AppendReflected(nil); err != nil {
+					return fmt.Errorf("appending array: %w", err)
+				}
+
+				continue
+			}
+
+			if err := enc.AppendArray(zapArray(array.MarshalLogArray())); err != nil {
+				return fmt.Errorf("appending array: %w", err)
+			}
+		case GroupType:
+			group := field.Interface.([]Field) //nolint: forcetypeassert // We know the type.
+			if err := enc.AppendObject(zapFields(group)); err != nil {
+				return fmt.Errorf("appending group: %w", err)
+			}
+		case SecretType:
+			enc.AppendString(redactedValue)
+		case UnkownType:
+			fallthrough
+		default:
+			if err := enc.AppendReflected(field.Interface); err != nil {
+				return fmt.Errorf("appending reflected value: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func toZapField(field Field) zap.Field { //nolint: cyclop,funlen // Easier to read whole type switch.
+	switch field.Type {
+	case BoolType:
+		return zap.Bool(field.Key, field.Integer == 1)
+	case ByteStringType:
+		return zap.ByteString(field.Key, field.Interface.([]byte)) //nolint: forcetypeassert // We know the type.
+	case DurationType:
+		return zap.Duration(field.Key, time.Duration(field.Integer))
+	case ErrorType:
+		err, _ := field.Interface.(error) // Nil errors are skipped by zap.NamedError.
+		return zap.NamedError(field.Key, err)
+	case Float32Type:
+		return zap.Float32(field.Key, math.Float32frombits(uint32(field.Integer)))
+	case Float64Type:
+		return zap.Float64(field.Key, math.Float64frombits(uint64(field.Integer)))
+	case IntType:
+		return zap.Int(field.Key, int(field.Integer))
+	case Int8Type:
+		return zap.Int8(field.Key, int8(field.Integer))
+	case Int16Type:
+		return zap.Int16(field.Key, int16(field.Integer))
+	case Int32Type:
+		return zap.Int32(field.Key, int32(field.Integer))
+	case Int64Type:
+		return zap.Int64(field.Key, field.Integer)
+	case UintType:
+		return zap.Uint(field.Key, uint(field.Integer))
+	case Uint8Type:
+		return zap.Uint8(field.Key, uint8(field.Integer))
+	case Uint16Type:
+		return zap.Uint16(field.Key, uint16(field.Integer))
+	case Uint32Type:
+		return zap.Uint32(field.Key, uint32(field.Integer))
+	case Uint64Type:
+		return zap.Uint64(field.Key, uint64(field.Integer))
+	case UintptrType:
+		return zap.Uintptr(field.Key, uintptr(field.Integer))
+	case StringType:
+		return zap.String(field.Key, field.String)
+	case TimeType:
+		return zap.Time(field.Key, field.timeValue())
+	case ObjectType:
+		object, ok := field.Interface.(ObjectMarshaler)
+		if !ok {
+			return zap.Reflect(field.Key, nil)
+		}
+
+		return zap.Object(field.Key, zapFields(object.MarshalLogObject()))
+	case ArrayType:
+		array, ok := field.Interface.(ArrayMarshaler)
+		if !ok {
+			return zap.Reflect(field.Key, nil)
+		}
+
+		return zap.Array(field.Key, zapArray(array.MarshalLogArray()))
+	case GroupType:
+		return zap.Object(field.Key, zapFields(field.Interface.([]Field))) //nolint: forcetypeassert // We know the type.
+	case SecretType:
+		return zap.String(field.Key, redactedValue)
+	case UnkownType:
+		fallthrough
+	default:
+		return zap.Any(field.Key, field.Interface)
+	}
+}
+
+func toZapLevel(level Level) zapcore.Level {
+	switch level {
+	case DebugLevel:
+		return zapcore.DebugLevel
+	case InfoLevel:
+		return zapcore.InfoLevel
+	case WarnLevel:
+		return zapcore.WarnLevel
+	case ErrorLevel:
+		return zapcore.ErrorLevel
+	case PanicLevel:
+		return zapcore.PanicLevel
+	case FatalLevel:
+		return zapcore.FatalLevel
+	default:
+		panic(fmt.Sprintf("log level unexpected: %d", level))
+	}
+}
+
+// zapCore is a zapcore.Core that writes entries to a Logger.
+type zapCore struct {
+	logger *Logger
+}
+
+// NewZapCore creates a zapcore.Core that writes every entry to the given Logger. This allows code
+// that still depends on a *zap.Logger to write through the same Logger as the rest of the application
+// by passing the core to zap.New.
+func NewZapCore(logger *Logger) zapcore.Core { //nolint: ireturn // zap.New accepts a zapcore.Core.
+	return zapCore{logger: logger}
+}
+
+func (c zapCore) Enabled(level zapcore.Level) bool {
+	return c.logger.Enabled(fromZapLevel(level))
+}
+
+func (c zapCore) With(fields []zapcore.Field) zapcore.Core { //nolint: ireturn // Required by zapcore.Core.
+	return zapCore{logger: c.logger.With(fromZapFields(fields)...)}
+}
+
+func (c zapCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+func (c zapCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	logger := c.logger.Named(entry.LoggerName)
+	logger.logAt(context.Background(), entry.Caller.PC, fromZapLevel(entry.Level), entry.Message, fromZapFields(fields))
+
+	return nil
+}
+
+func (c zapCore) Sync() error {
+	return c.logger.Sync(context.Background())
+}
+
+func fromZapFields(fields []zapcore.Field) []Field {
+	converted := make([]Field, 0, len(fields))
+
+	for _, field := range fields {
+		if field.Type == zapcore.SkipType {
+			continue
+		}
+
+		converted = append(converted, fromZapField(field))
+	}
+
+	return converted
+}
+
+func fromZapField(field zapcore.Field) Field { //nolint: cyclop,funlen // Easier to read whole type switch.
+	switch field.Type {
+	case zapcore.BoolType:
+		return Bool(field.Key, field.Integer == 1)
+	case zapcore.ByteStringType:
+		return ByteStr(field.Key, field.Interface.([]byte)) //nolint: forcetypeassert // We know the type.
+	case zapcore.DurationType:
+		return Duration(field.Key, time.Duration(field.Integer))
+	case zapcore.ErrorType:
+		err, _ := field.Interface.(error)
+		return NamedErr(field.Key, err)
+	case zapcore.Float32Type:
+		return Float(field.Key, math.Float32frombits(uint32(field.Integer)))
+	case zapcore.Float64Type:
+		return Float(field.Key, math.Float64frombits(uint64(field.Integer)))
+	case zapcore.Int8Type:
+		return Integer(field.Key, int8(field.Integer))
+	case zapcore.Int16Type:
+		return Integer(field.Key, int16(field.Integer))
+	case zapcore.Int32Type:
+		return Integer(field.Key, int32(field.Integer))
+	case zapcore.Int64Type:
+		return Integer(field.Key, field.Integer)
+	case zapcore.Uint8Type:
+		return Integer(field.Key, uint8(field.Integer))
+	case zapcore.Uint16Type:
+		return Integer(field.Key, uint16(field.Integer))
+	case zapcore.Uint32Type:
+		return Integer(field.Key, uint32(field.Integer))
+	case zapcore.Uint64Type:
+		return Integer(field.Key, uint64(field.Integer))
+	case zapcore.UintptrType:
+		return Integer(field.Key, uintptr(field.Integer))
+	case zapcore.StringType:
+		return Str(field.Key, field.String)
+	case zapcore.StringerType:
+		stringer, ok := field.Interface.(fmt.Stringer)
+		if !ok {
+			// Nil stringers are written as null, as nil errors are.
+			return Field{Type: UnkownType, Key: field.Key, Integer: 0, String: "", Interface: nil}
+		}
+
+		return Str(field.Key, stringer.String())
+	case zapcore.TimeType:
+		if location, ok := field.Interface.(*time.Location); ok {
+			return Time(field.Key, time.Unix(0, field.Integer).In(location))
+		}
+
+		return Time(field.Key, time.Unix(0, field.Integer))
+	case zapcore.TimeFullType:
+		return Time(field.Key, field.Interface.(time.Time)) //nolint: forcetypeassert // We know the type.
+	default:
+		// Complex types such as objects and arrays are encoded by zap so that we do not lose their structure.
+		enc := zapcore.NewMapObjectEncoder()
+		field.AddTo(enc)
+
+		return Field{Type: UnkownType, Key: field.Key, Integer: 0, String: "", Interface: enc.Fields[field.Key]}
+	}
+}
+
+func fromZapLevel(level zapcore.Level) Level {
+	switch level {
+	case zapcore.DebugLevel:
+		return DebugLevel
+	case zapcore.InfoLevel:
+		return InfoLevel
+	case zapcore.WarnLevel:
+		return WarnLevel
+	case zapcore.ErrorLevel, zapcore.DPanicLevel:
+		return ErrorLevel
+	case zapcore.PanicLevel:
+		return PanicLevel
+	case zapcore.FatalLevel:
+		return FatalLevel
+	case zapcore.InvalidLevel:
+		fallthrough
+	default:
+		return DebugLevel // Fallback to DebugLevel for full log output.
+	}
+}
@@ -0,0 +1,315 @@
+package lgr_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/nickbryan/collectable/libraries/lgr"
+	"github.com/nickbryan/collectable/libraries/lgr/lgrtest"
+)
+
+func TestZapAdapter(t *testing.T) {
+	t.Parallel()
+
+	// Used to assert time for time fields. The observer encodes times without the monotonic clock reading.
+	now := time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		log         func(logger *lgr.Logger)
+		wantLevel   zapcore.Level
+		wantMsg     string
+		wantName    string
+		wantContext map[string]any
+	}{
+		"logs debug message": {
+			log:         func(logger *lgr.Logger) { logger.Debug("my debug message") },
+			wantLevel:   zapcore.DebugLevel,
+			wantMsg:     "my debug message",
+			wantContext: map[string]any{},
+		},
+		"logs info message": {
+			log:         func(logger *lgr.Logger) { logger.Info("my info message") },
+			wantLevel:   zapcore.InfoLevel,
+			wantMsg:     "my info message",
+			wantContext: map[string]any{},
+		},
+		"logs warn message": {
+			log:         func(logger *lgr.Logger) { logger.Warn("my warn message") },
+			wantLevel:   zapcore.WarnLevel,
+			wantMsg:     "my warn message",
+			wantContext: map[string]any{},
+		},
+		"logs error message": {
+			log:         func(logger *lgr.Logger) { logger.Error("my error message") },
+			wantLevel:   zapcore.ErrorLevel,
+			wantMsg:     "my error message",
+			wantContext: map[string]any{},
+		},
+		"sets logger name": {
+			log:         func(logger *lgr.Logger) { logger.Named("iam").Named("identity").Info("my info message") },
+			wantLevel:   zapcore.InfoLevel,
+			wantMsg:     "my info message",
+			wantName:    "iam.identity",
+			wantContext: map[string]any{},
+		},
+		"sets fields": {
+			log: func(logger *lgr.Logger) {
+				logger.With(lgr.Str("requestId", "abc-123")).Info(
+					"my info message",
+					lgr.Bool("boolKey", true),
+					lgr.ByteStr("byteStrKey", []byte("some byte string")),
+					lgr.Duration("durationKey", time.Second),
+					lgr.NamedErr("errKey", errors.New("some error string")),
+					lgr.Float("float32Key", float32(1.5)),
+					lgr.Float("float64Key", float64(2.5)),
+					lgr.Integer("intKey", 1),
+					lgr.Integer("int8Key", int8(2)),
+					lgr.Integer("int16Key", int16(3)),
+					lgr.Integer("int32Key", int32(4)),
+					lgr.Integer("int64Key", int64(5)),
+					lgr.Integer("uintKey", uint(6)),
+					lgr.Integer("uint8Key", uint8(7)),
+					lgr.Integer("uint16Key", uint16(8)),
+					lgr.Integer("uint32Key", uint32(9)),
+					lgr.Integer("uint64Key", uint64(10)),
+					lgr.Integer("uintptrKey", uintptr(11)),
+					lgr.Time("timeKey", now),
+					lgr.Field{Type: lgr.UnkownType, Key: "unknownKey", Integer: 0, String: "", Interface: []int{1, 2}},
+				)
+			},
+			wantLevel: zapcore.InfoLevel,
+			wantMsg:   "my info message",
+			wantContext: map[string]any{
+				"requestId":   "abc-123",
+				"boolKey":     true,
+				"byteStrKey":  "some byte string",
+				"durationKey": time.Second,
+				"errKey":      "some error string",
+				"float32Key":  float32(1.5),
+				"float64Key":  float64(2.5),
+				"intKey":      int64(1),
+				"int8Key":     int8(2),
+				"int16Key":    int16(3),
+				"int32Key":    int32(4),
+				"int64Key":    int64(5),
+				"uintKey":     uint64(6),
+				"uint8Key":    uint8(7),
+				"uint16Key":   uint16(8),
+				"uint32Key":   uint32(9),
+				"uint64Key":   uint64(10),
+				"uintptrKey":  uintptr(11),
+				"timeKey":     now,
+				"unknownKey":  []any{1, 2},
+			},
+		},
+		"handles nil errors": {
+			log: func(logger *lgr.Logger) {
+				logger.Info("my info message", lgr.Err(nil), lgr.Array("arrayKey", chain{lgr.Err(nil)}))
+			},
+			wantLevel:   zapcore.InfoLevel,
+			wantMsg:     "my info message",
+			wantContext: map[string]any{"arrayKey": []any{nil}},
+		},
+		"handles nil objects and arrays": {
+			log: func(logger *lgr.Logger) {
+				logger.Info(
+					"my info message",
+					lgr.Object("objectKey", nil),
+					lgr.Array("arrayKey", nil),
+					lgr.Array("nestedKey", chain{lgr.Object("o", nil), lgr.Array("a", nil)}),
+				)
+			},
+			wantLevel:   zapcore.InfoLevel,
+			wantMsg:     "my info message",
+			wantContext: map[string]any{"objectKey": nil, "arrayKey": nil, "nestedKey": []any{nil, nil}},
+		},
+		"sets nested fields": {
+			log: func(logger *lgr.Logger) {
+				logger.Info(
+					"my info message",
+					lgr.Object("objectKey", testObject{name: "some name"}),
+					lgr.Group("groupKey", lgr.Str("nestedKey", "some nested string")),
+					lgr.Array("arrayKey", testArray{"a", "b"}),
+					lgr.Durations("durationsKey", []time.Duration{time.Second}),
+					lgr.Ints("intsKey", []uint16{1, 2}),
+				)
+			},
+			wantLevel: zapcore.InfoLevel,
+			wantMsg:   "my info message",
+			wantContext: map[string]any{
+				"objectKey":    map[string]any{"name": "some name"},
+				"groupKey":     map[string]any{"nestedKey": "some nested string"},
+				"arrayKey":     []any{"a", "b"},
+				"durationsKey": []any{time.Second},
+				"intsKey":      []any{uint16(1), uint16(2)},
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		tn, tc := testName, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			core, observed := observer.New(zapcore.DebugLevel)
+			tc.log(lgr.FromAdapter(lgr.NewZapAdapter(zap.New(core))))
+
+			logs := observed.All()
+			assert.Len(t, logs, 1)
+			assert.Equal(t, tc.wantLevel, logs[0].Level)
+			assert.Equal(t, tc.wantMsg, logs[0].Message)
+			assert.Equal(t, tc.wantName, logs[0].LoggerName)
+			assert.Equal(t, map[string]any{"context": tc.wantContext}, logs[0].ContextMap())
+		})
+	}
+
+	t.Run("appends logger name to zap logger name", func(t *testing.T) {
+		t.Parallel()
+
+		core, observed := observer.New(zapcore.DebugLevel)
+		lgr.FromAdapter(lgr.NewZapAdapter(zap.New(core).Named("gateway"))).Named("rest").Info("my info message")
+
+		assert.Equal(t, "gateway.rest", observed.All()[0].LoggerName)
+	})
+
+	t.Run("writes panic and fatal levels without panicking or exiting", func(t *testing.T) {
+		t.Parallel()
+
+		core, observed := observer.New(zapcore.DebugLevel)
+		adapter := lgr.NewZapAdapter(zap.New(core))
+
+		adapter.Adapt(lgr.PanicLevel, "my panic message")
+		adapter.Adapt(lgr.FatalLevel, "my fatal message")
+
+		logs := observed.All()
+		assert.Len(t, logs, 2)
+		assert.Equal(t, zapcore.PanicLevel, logs[0].Level)
+		assert.Equal(t, zapcore.FatalLevel, logs[1].Level)
+	})
+
+	t.Run("respects the zap logger level", func(t *testing.T) {
+		t.Parallel()
+
+		core, observed := observer.New(zapcore.WarnLevel)
+		logger := lgr.FromAdapter(lgr.NewZapAdapter(zap.New(core)))
+
+		logger.Info("my info message")
+		logger.Warn("my warn message")
+
+		assert.Equal(t, 1, observed.Len())
+		assert.Equal(t, "my warn message", observed.All()[0].Message)
+	})
+}
+
+func TestZapCore(t *testing.T) {
+	t.Parallel()
+
+	// Used to assert time for time fields.
+	now := time.Now()
+
+	t.Run("writes each level to the logger", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		zlogger := zap.New(lgr.NewZapCore(logger))
+
+		zlogger.Debug("my debug message")
+		zlogger.Info("my info message")
+		zlogger.Warn("my warn message")
+		zlogger.Error("my error message")
+		zlogger.DPanic("my dpanic message")
+
+		logs := entries.All()
+		assert.Len(t, logs, 5)
+
+		lgrtest.AssertFullEntry(t, logs[0], lgr.DebugLevel, "my debug message")
+		lgrtest.AssertFullEntry(t, logs[1], lgr.InfoLevel, "my info message")
+		lgrtest.AssertFullEntry(t, logs[2], lgr.WarnLevel, "my warn message")
+		lgrtest.AssertFullEntry(t, logs[3], lgr.ErrorLevel, "my error message")
+		lgrtest.AssertFullEntry(t, logs[4], lgr.ErrorLevel, "my dpanic message")
+	})
+
+	t.Run("converts zap fields", func(t *testing.T) {
+		t.Parallel()
+
+		logger, entries := lgrtest.New()
+		err := errors.New("some error string")
+
+		zap.New(lgr.NewZapCore(logger)).With(zap.String("requestId", "abc-123")).Info(
+			"my info message",
+			zap.Bool("boolKey", true),
+			zap.ByteString("byteStrKey", []byte("some byte string")),
+			zap.Duration("durationKey", time.Second),
+			zap.NamedError("errKey", err),
+			zap.Float32("float32Key", 1.5),
+			zap.Float64("float64Key", 2.5),
+			zap.Int8("int8Key", 2),
+			zap.Int16("int16Key", 3),
+			zap.Int32("int32Key", 4),
+			zap.Int64("int64Key", 5),
+			zap.Uint8("uint8Key", 7),
+			zap.Uint16("uint16Key", 8),
+			zap.Uint32("uint32Key", 9),
+			zap.Uint64("uint64Key", 10),
+			zap.Uintptr("uintptrKey", 11),
+			zap.Stringer("stringerKey", time.Minute),
+			zap.Stringer("nilStringerKey", nil),
+			zap.Time("timeKey", now.In(time.UTC)),
+			zap.Ints("intsKey", []int{1, 2}),
+			zap.Skip(),
+		)
+
+		lgrtest.AssertFullEntry(
+			t,
+			entries.Idx(0),
+			lgr.InfoLevel,
+			"my info message",
+			lgr.Str("requestId", "abc-123"),
+			lgr.Bool("boolKey", true),
+			lgr.ByteStr("byteStrKey", []byte("some byte string")),
+			lgr.Duration("durationKey", time.Second),
+			lgr.NamedErr("errKey", err),
+			lgr.Float("float32Key", float32(1.5)),
+			lgr.Float("float64Key", float64(2.5)),
+			lgr.Integer("int8Key", int8(2)),
+			lgr.Integer("int16Key", int16(3)),
+			lgr.Integer("int32Key", int32(4)),
+			lgr.Integer("int64Key", int64(5)),
+			lgr.Integer("uint8Key", uint8(7)),
+			lgr.Integer("uint16Key", uint16(8)),
+			lgr.Integer("uint32Key", uint32(9)),
+			lgr.Integer("uint64Key", uint64(10)),
+			lgr.Integer("uintptrKey", uintptr(11)),
+			lgr.Str("stringerKey", "1m0s"),
+			lgr.Field{Type: lgr.UnkownType, Key: "nilStringerKey", Integer: 0, String: "", Interface: nil},
+			lgr.Time("timeKey", time.Unix(0, now.UnixNano()).In(time.UTC)),
+			lgr.Field{Type: lgr.UnkownType, Key: "intsKey", Integer: 0, String: "", Interface: []any{1, 2}},
+		)
+	})
+
+	t.Run("respects the minimum level of the logger", func(t *testing.T) {
+		t.Parallel()
+
+		core := lgr.NewZapCore(lgr.FromAdapter(nil, lgr.WithMinLevel(lgr.WarnLevel)))
+
+		assert.False(t, core.Enabled(zapcore.InfoLevel))
+		assert.False(t, lgr.NewZapCore(lgr.NewNop()).Enabled(zapcore.ErrorLevel))
+	})
+
+	t.Run("is nil safe", func(t *testing.T) {
+		t.Parallel()
+
+		zlogger := zap.New(lgr.NewZapCore(lgr.NewNop()))
+
+		// No assertions as nil logger would panic on receiver normally causing the test to fail.
+		zlogger.With(zap.String("strKey", "some string")).Named("child").Error("my error message")
+		assert.NoError(t, zlogger.Sync())
+	})
+}
@@ -1,131 +1,243 @@
 package lgr
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"os"
+	"math"
 	"time"
 
 	"github.com/rs/zerolog"
 )
 
+// zerologDefaults are the settings used by the zerologAdapter when they are not set in its EncoderConfig.
+var zerologDefaults = EncoderConfig{
+	TimestampKey: "timestamp",
+	LevelKey:     "level",
+	MessageKey:   "message",
+	ContextKey:   "context",
+	TimeFormat:   time.RFC3339,
+	DurationUnit: time.Millisecond,
+	Host:         "",
+}
+
 type zerologAdapter struct {
 	logger zerolog.Logger
+	file   *logFile
+	config EncoderConfig
 }
 
-func bindZerologAdapter(logger *Logger) error {
-	var output io.Writer
+// newZerologAdapter creates a zerologAdapter that writes JSON logs to the given output with the keys and
+// formats of the config. The file should be set when the output is a file so that it can be synced.
+func newZerologAdapter(output io.Writer, file *logFile, config EncoderConfig) zerologAdapter {
+	// The minimum level is checked by the Logger so that it can be changed at runtime and the timestamp
+	// is taken from the entry so that it reflects when the log was written rather than when it was adapted.
+	zlogger := zerolog.New(output)
 
-	switch logger.outputPath {
-	case "stdout":
-		output = os.Stdout
-	case "stderr":
-		output = os.Stderr
-	default:
-		const ownerRWOnly = 0x600
+	return zerologAdapter{logger: zlogger, file: file, config: config}
+}
 
-		f, err := os.OpenFile(logger.outputPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, ownerRWOnly)
-		if err != nil {
-			return fmt.Errorf("opening output path file: %w", err)
-		}
+func (z zerologAdapter) Adapt(level Level, message string, fields ...Field) {
+	z.AdaptEntry(newEntry(level, message, fields))
+}
+
+func (z zerologAdapter) AdaptEntry(entry Entry) {
+	if entry.Level < DebugLevel || entry.Level > FatalLevel {
+		panic(fmt.Sprintf("log level unexpected: %d", entry.Level))
+	}
+
+	// The level, message and timestamp are written by the adapter rather than by zerolog as zerolog reads
+	// their keys and formats from package level variables that would be shared by every Logger.
+	config := z.config.withDefaults(zerologDefaults)
 
-		output = f
+	// Unlike Panic and Fatal, Log does not stop the flow of the program as that is handled by Logger.
+	event := z.logger.Log()
+	event.Str(config.LevelKey, entry.Level.String())
+
+	if entry.LoggerName != "" {
+		event.Str("logger", entry.LoggerName)
 	}
 
-	zerolog.TimestampFieldName = "timestamp"
-	zerolog.TimestampFunc = logger.timestampFactory
-	zlogger := zerolog.New(output).Level(toZerologLevel(logger.minLevel)).With().Timestamp().Logger()
+	if entry.Caller.Defined {
+		event.Str("caller", entry.Caller.String())
+	}
 
-	logger.adapter = zerologAdapter{logger: zlogger}
+	if len(entry.Stack) > 0 {
+		event.Strs("stack", entry.Stack)
+	}
+
+	event.Dict(config.ContextKey, fieldsToContext(entry.Fields, config))
+
+	if !entry.Time.IsZero() {
+		event.Str(config.TimestampKey, entry.Time.Format(config.TimeFormat))
+	}
+
+	if entry.Message != "" {
+		event.Str(config.MessageKey, entry.Message)
+	}
+
+	event.Send()
+}
+
+// Sync flushes the output file to disk. It is a no-op when writing to stdout or stderr.
+func (z zerologAdapter) Sync(_ context.Context) error {
+	if z.file == nil {
+		return nil
+	}
+
+	if err := z.file.Sync(); err != nil {
+		return fmt.Errorf("syncing output path file: %w", err)
+	}
 
 	return nil
 }
 
-func (z zerologAdapter) Adapt(level Level, message string, fields ...Field) {
-	var event *zerolog.Event
-
-	switch level {
-	case DebugLevel:
-		event = z.logger.Debug()
-	case InfoLevel:
-		event = z.logger.Info()
-	case WarnLevel:
-		event = z.logger.Warn()
-	case ErrorLevel:
-		event = z.logger.Error()
-	default:
-		panic(fmt.Sprintf("log level unexpected: %v", level))
+// Close closes the output file. It is a no-op when writing to stdout or stderr.
+func (z zerologAdapter) Close() error {
+	if z.file == nil {
+		return nil
 	}
 
-	event.Dict("context", fieldsToContext(fields))
+	if err := z.file.Close(); err != nil {
+		return fmt.Errorf("closing output path file: %w", err)
+	}
 
-	event.Msg(message)
+	return nil
 }
 
-func fieldsToContext(fields []Field) *zerolog.Event { //nolint: cyclop,funlen // Easier to read whole type switch.
+func fieldsToContext(fields []Field, config EncoderConfig) *zerolog.Event { //nolint: cyclop,funlen // Easier to read whole type switch.
 	event := zerolog.Dict()
 
 	for _, field := range fields {
 		switch field.Type {
 		case BoolType:
-			event.Bool(field.Key, field.Value.(bool)) //nolint: forcetypeassert // We know the type.
+			event.Bool(field.Key, field.Integer == 1)
 		case ByteStringType:
-			event.Bytes(field.Key, field.Value.([]byte)) //nolint: forcetypeassert // We know the type.
+			event.Bytes(field.Key, field.Interface.([]byte)) //nolint: forcetypeassert // We know the type.
 		case DurationType:
-			event.Dur(field.Key, field.Value.(time.Duration)) //nolint: forcetypeassert // We know the type.
+			event.Float64(field.Key, float64(field.Integer)/float64(config.DurationUnit))
 		case ErrorType:
-			event.AnErr(field.Key, field.Value.(error)) //nolint: forcetypeassert // We know the type.
+			err, _ := field.Interface.(error) // Nil errors are skipped by zerolog.
+			event.AnErr(field.Key, err)
 		case Float32Type:
-			event.Float32(field.Key, field.Value.(float32)) //nolint: forcetypeassert // We know the type.
+			event.Float32(field.Key, math.Float32frombits(uint32(field.Integer)))
 		case Float64Type:
-			event.Float64(field.Key, field.Value.(float64)) //nolint: forcetypeassert // We know the type.
+			event.Float64(field.Key, math.Float64frombits(uint64(field.Integer)))
 		case IntType:
-			event.Int(field.Key, field.Value.(int)) //nolint: forcetypeassert // We know the type.
+			event.Int(field.Key, int(field.Integer))
 		case Int8Type:
-			event.Int8(field.Key, field.Value.(int8)) //nolint: forcetypeassert // We know the type.
+			event.Int8(field.Key, int8(field.Integer))
 		case Int16Type:
-			event.Int16(field.Key, field.Value.(int16)) //nolint: forcetypeassert // We know the type.
+			event.Int16(field.Key, int16(field.Integer))
 		case Int32Type:
-			event.Int32(field.Key, field.Value.(int32)) //nolint: forcetypeassert // We know the type.
+			event.Int32(field.Key, int32(field.Integer))
 		case Int64Type:
-			event.Int64(field.Key, field.Value.(int64)) //nolint: forcetypeassert // We know the type.
+			event.Int64(field.Key, field.Integer)
 		case UintType:
-			event.Uint(field.Key, field.Value.(uint)) //nolint: forcetypeassert // We know the type.
+			event.Uint(field.Key, uint(field.Integer))
 		case Uint8Type:
-			event.Uint8(field.Key, field.Value.(uint8)) //nolint: forcetypeassert // We know the type.
+			event.Uint8(field.Key, uint8(field.Integer))
 		case Uint16Type:
-			event.Uint16(field.Key, field.Value.(uint16)) //nolint: forcetypeassert // We know the type.
+			event.Uint16(field.Key, uint16(field.Integer))
 		case Uint32Type:
-			event.Uint32(field.Key, field.Value.(uint32)) //nolint: forcetypeassert // We know the type.
+			event.Uint32(field.Key, uint32(field.Integer))
 		case Uint64Type:
-			event.Uint64(field.Key, field.Value.(uint64)) //nolint: forcetypeassert // We know the type.
+			event.Uint64(field.Key, uint64(field.Integer))
 		case UintptrType:
-			event.Uint64(field.Key, uint64(field.Value.(uintptr))) //nolint: forcetypeassert // We know the type.
+			event.Uint64(field.Key, uint64(field.Integer))
 		case StringType:
-			event.Str(field.Key, field.Value.(string)) //nolint: forcetypeassert // We know the type.
+			event.Str(field.Key, field.String)
 		case TimeType:
-			event.Time(field.Key, field.Value.(time.Time)) //nolint: forcetypeassert // We know the type.
+			event.Str(field.Key, field.timeValue().Format(config.TimeFormat))
+		case ObjectType:
+			if object, ok := field.Interface.(ObjectMarshaler); ok {
+				event.Dict(field.Key, fieldsToContext(object.MarshalLogObject(), config))
+			} else {
+				event.Interface(field.Key, nil)
+			}
+		case ArrayType:
+			if array, ok := field.Interface.(ArrayMarshaler); ok {
+				event.Array(field.Key, fieldsToArray(array.MarshalLogArray(), config))
+			} else {
+				event.Interface(field.Key, nil)
+			}
+		case GroupType:
+			event.Dict(field.Key, fieldsToContext(field.Interface.([]Field), config)) //nolint: forcetypeassert // We know the type.
+		case SecretType:
+			event.Str(field.Key, redactedValue)
 		case UnkownType:
 			fallthrough
 		default:
-			event.Interface(field.Key, field.Value)
+			event.Interface(field.Key, field.Interface)
 		}
 	}
 
 	return event
 }
 
-func toZerologLevel(l Level) zerolog.Level {
-	switch l {
-	case DebugLevel:
-		return zerolog.DebugLevel
-	case InfoLevel:
-		return zerolog.InfoLevel
-	case WarnLevel:
-		return zerolog.WarnLevel
-	case ErrorLevel:
-		return zerolog.ErrorLevel
-	default:
-		return zerolog.DebugLevel // Fallback to DebugLevel for full log output.
+func fieldsToArray(fields []Field, config EncoderConfig) *zerolog.Array { //nolint: cyclop,funlen // Easier to read whole type switch.
+	arr := zerolog.Arr()
+
+	for _, field := range fields {
+		switch field.Type {
+		case BoolType:
+			arr.Bool(field.Integer == 1)
+		case ByteStringType:
+			arr.Bytes(field.Interface.([]byte)) //nolint: forcetypeassert // We know the type.
+		case DurationType:
+			arr.Float64(float64(field.Integer) / float64(config.DurationUnit))
+		case ErrorType:
+			err, _ := field.Interface.(error) // Nil errors are written as null by zerolog.
+			arr.Err(err)
+		case Float32Type:
+			arr.Float32(math.Float32frombits(uint32(field.Integer)))
+		case Float64Type:
+			arr.Float64(math.Float64frombits(uint64(field.Integer)))
+		case IntType:
+			arr.Int(int(field.Integer))
+		case Int8Type:
+			arr.Int8(int8(field.Integer))
+		case Int16Type:
+			arr.Int16(int16(field.Integer))
+		case Int32Type:
+			arr.Int32(int32(field.Integer))
+		case Int64Type:
+			arr.Int64(field.Integer)
+		case UintType:
+			arr.Uint(uint(field.Integer))
+		case Uint8Type:
+			arr.Uint8(uint8(field.Integer))
+		case Uint16Type:
+			arr.Uint16(uint16(field.Integer))
+		case Uint32Type:
+			arr.Uint32(uint32(field.Integer))
+		case Uint64Type:
+			arr.Uint64(uint64(field.Integer))
+		case UintptrType:
+			arr.Uint64(uint64(field.Integer))
+		case StringType:
+			arr.Str(field.String)
+		case TimeType:
+			arr.Str(field.timeValue().Format(config.TimeFormat))
+		case ObjectType:
+			if object, ok := field.Interface.(ObjectMarshaler); ok {
+				arr.Dict(fieldsToContext(object.MarshalLogObject(), config))
+			} else {
+				arr.Interface(nil)
+			}
+		case GroupType:
+			arr.Dict(fieldsToContext(field.Interface.([]Field), config)) //nolint: forcetypeassert // We know the type.
+		case ArrayType:
+			// Zerolog does not support nesting arrays so fall back to encoding the values via reflection.
+			arr.Interface(fieldToValue(field))
+		case SecretType:
+			arr.Str(redactedValue)
+		case UnkownType:
+			fallthrough
+		default:
+			arr.Interface(field.Interface)
+		}
 	}
+
+	return arr
 }
@@ -52,60 +52,120 @@ func TestZerologAdapter(t *testing.T) {
 			msg:   "my error message",
 			want:  `{"level":"error","context":{},"message":"my error message"}`,
 		},
+		"logs panic message without panicking": {
+			level: PanicLevel,
+			msg:   "my panic message",
+			want:  `{"level":"panic","context":{},"message":"my panic message"}`,
+		},
+		"logs fatal message without exiting": {
+			level: FatalLevel,
+			msg:   "my fatal message",
+			want:  `{"level":"fatal","context":{},"message":"my fatal message"}`,
+		},
 		"sets bool field": {
-			fields: []Field{{Type: BoolType, Key: "boolFieldKey", Value: true}},
+			fields: []Field{Bool("boolFieldKey", true)},
 			want:   `{"level":"info","context":{"boolFieldKey":true}}`,
 		},
 		"sets byte string field": {
-			fields: []Field{{Type: ByteStringType, Key: "byteStringFieldKey", Value: []byte("some byte string")}},
+			fields: []Field{ByteStr("byteStringFieldKey", []byte("some byte string"))},
 			want:   `{"level":"info","context":{"byteStringFieldKey":"some byte string"}}`,
 		},
 		"sets duration field": {
-			fields: []Field{{Type: DurationType, Key: "durationFieldKey", Value: time.Second}},
+			fields: []Field{Duration("durationFieldKey", time.Second)},
 			want:   `{"level":"info","context":{"durationFieldKey":1000}}`,
 		},
 		"sets error field": {
-			fields: []Field{{Type: ErrorType, Key: "errorFieldKey", Value: errors.New("some error string")}},
+			fields: []Field{NamedErr("errorFieldKey", errors.New("some error string"))},
 			want:   `{"level":"info","context":{"errorFieldKey":"some error string"}}`,
 		},
 		"sets float fields": {
 			fields: []Field{
-				{Type: Float32Type, Key: "float32FieldKey", Value: float32(123)},
-				{Type: Float64Type, Key: "float64FieldKey", Value: float64(456)},
+				Float("float32FieldKey", float32(123)),
+				Float("float64FieldKey", 456.0),
 			},
 			want: `{"level":"info","context":{"float32FieldKey":123,"float64FieldKey":456}}`,
 		},
 		"sets int fields": {
 			fields: []Field{
-				{Type: IntType, Key: "intFieldKey", Value: int(1)},
-				{Type: Int8Type, Key: "int8FieldKey", Value: int8(2)},
-				{Type: Int16Type, Key: "int16FieldKey", Value: int16(3)},
-				{Type: Int32Type, Key: "int32FieldKey", Value: int32(4)},
-				{Type: Int64Type, Key: "int64FieldKey", Value: int64(5)},
+				Integer("intFieldKey", 1),
+				Integer("int8FieldKey", int8(2)),
+				Integer("int16FieldKey", int16(3)),
+				Integer("int32FieldKey", int32(4)),
+				Integer("int64FieldKey", int64(5)),
 			},
 			want: `{"level":"info","context":{"intFieldKey":1,"int8FieldKey":2,"int16FieldKey":3,"int32FieldKey":4,"int64FieldKey":5}}`,
 		},
 		"sets uint fields": {
 			fields: []Field{
-				{Type: UintType, Key: "uintFieldKey", Value: uint(1)},
-				{Type: Uint8Type, Key: "uint8FieldKey", Value: uint8(2)},
-				{Type: Uint16Type, Key: "uint16FieldKey", Value: uint16(3)},
-				{Type: Uint32Type, Key: "uint32FieldKey", Value: uint32(4)},
-				{Type: Uint64Type, Key: "uint64FieldKey", Value: uint64(5)},
-				{Type: UintptrType, Key: "uintptrFieldKey", Value: uintptr(6)},
+				Integer("uintFieldKey", uint(1)),
+				Integer("uint8FieldKey", uint8(2)),
+				Integer("uint16FieldKey", uint16(3)),
+				Integer("uint32FieldKey", uint32(4)),
+				Integer("uint64FieldKey", uint64(5)),
+				Integer("uintptrFieldKey", uintptr(6)),
 			},
 			want: `{"level":"info","context":{"uintFieldKey":1,"uint8FieldKey":2,"uint16FieldKey":3,"uint32FieldKey":4,"uint64FieldKey":5,"uintptrFieldKey":6}}`,
 		},
 		"sets string field": {
-			fields: []Field{{Type: StringType, Key: "stringFieldKey", Value: "some string"}},
+			fields: []Field{Str("stringFieldKey", "some string")},
 			want:   `{"level":"info","context":{"stringFieldKey":"some string"}}`,
 		},
 		"sets time field": {
-			fields: []Field{{Type: TimeType, Key: "timeFieldKey", Value: now}},
+			fields: []Field{Time("timeFieldKey", now)},
 			want:   fmt.Sprintf(`{"level":"info","context":{"timeFieldKey":"%s"}}`, now.Format(time.RFC3339)),
 		},
+		"sets object field": {
+			fields: []Field{Object("objectFieldKey", testObject{name: "some name", tags: []string{"a", "b"}})},
+			want:   `{"level":"info","context":{"objectFieldKey":{"name":"some name","tags":["a","b"]}}}`,
+		},
+		"sets array field": {
+			fields: []Field{Array("arrayFieldKey", testArray{
+				Bool("", true),
+				ByteStr("", []byte("some byte string")),
+				Duration("", time.Second),
+				Err(errors.New("some error string")),
+				Float("", float32(1.5)),
+				Float("", 2.5),
+				Integer("", 1),
+				Integer("", int8(2)),
+				Integer("", int16(3)),
+				Integer("", int32(4)),
+				Integer("", int64(5)),
+				Integer("", uint(6)),
+				Integer("", uint8(7)),
+				Integer("", uint16(8)),
+				Integer("", uint32(9)),
+				Integer("", uint64(10)),
+				Integer("", uintptr(11)),
+				Str("", "some string"),
+				Time("", time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)),
+				Object("", testObject{name: "some name", tags: nil}),
+				Group("", Str("nestedKey", "some nested string")),
+				Ints("", []int{1, 2}),
+				{Type: UnkownType, Key: "", Integer: 0, String: "", Interface: map[string]int{"unknown": 1}},
+			})},
+			want: `{"level":"info","context":{"arrayFieldKey":[true,"some byte string",1000,"some error string",` +
+				`1.5,2.5,1,2,3,4,5,6,7,8,9,10,11,"some string","2021-02-01T00:00:00Z",{"name":"some name","tags":[]},` +
+				`{"nestedKey":"some nested string"},[1,2],{"unknown":1}]}}`,
+		},
+		"sets group field": {
+			fields: []Field{Group("groupFieldKey", Str("stringFieldKey", "some string"), Group("nestedGroupKey"))},
+			want:   `{"level":"info","context":{"groupFieldKey":{"stringFieldKey":"some string","nestedGroupKey":{}}}}`,
+		},
+		"redacts secret field": {
+			fields: []Field{Secret("secretFieldKey", "some secret string")},
+			want:   `{"level":"info","context":{"secretFieldKey":"[REDACTED]"}}`,
+		},
+		"handles nil errors": {
+			fields: []Field{Err(nil), Array("arrayKey", testArray{Err(nil)})},
+			want:   `{"level":"info","context":{"arrayKey":[null]}}`,
+		},
+		"handles nil objects and arrays": {
+			fields: []Field{Object("objectKey", nil), Array("arrayKey", nil), Array("nestedKey", testArray{Object("o", nil), Array("a", nil)})},
+			want:   `{"level":"info","context":{"objectKey":null,"arrayKey":null,"nestedKey":[null,null]}}`,
+		},
 		"handles unknown field type": {
-			fields: []Field{{Type: UnkownType, Key: "unknownFieldKey", Value: struct{ thing int }{thing: 123}}},
+			fields: []Field{{Type: UnkownType, Key: "unknownFieldKey", Integer: 0, String: "", Interface: struct{ thing int }{thing: 123}}},
 			want:   `{"level":"info","context":{"unknownFieldKey":{}}}`,
 		},
 	}
@@ -124,18 +184,48 @@ func TestZerologAdapter(t *testing.T) {
 	}
 }
 
-func TestToZerologLevel(t *testing.T) {
+// testObject implements ObjectMarshaler for testing object fields.
+type testObject struct {
+	name string
+	tags []string
+}
+
+func (o testObject) MarshalLogObject() []Field {
+	return []Field{Str("name", o.name), Strs("tags", o.tags)}
+}
+
+// testArray implements ArrayMarshaler for testing array fields.
+type testArray []Field
+
+func (a testArray) MarshalLogArray() []Field {
+	return a
+}
+
+func TestZerologAdapterWithLogger(t *testing.T) {
 	t.Parallel()
 
 	testCases := map[string]struct {
-		level Level
-		want  zerolog.Level
+		log  func(logger *Logger)
+		want string
 	}{
-		"debug":            {level: DebugLevel, want: zerolog.DebugLevel},
-		"info":             {level: InfoLevel, want: zerolog.InfoLevel},
-		"warn":             {level: WarnLevel, want: zerolog.WarnLevel},
-		"error":            {level: ErrorLevel, want: zerolog.ErrorLevel},
-		"unknown/fallback": {level: Level(123), want: zerolog.DebugLevel},
+		"writes bound fields": {
+			log: func(logger *Logger) {
+				logger.With(Str("requestId", "abc-123")).Info("my info message", Bool("boolKey", true))
+			},
+			want: `{"level":"info","context":{"requestId":"abc-123","boolKey":true},"timestamp":"2022-03-05T00:00:00Z","message":"my info message"}`,
+		},
+		"writes logger name": {
+			log: func(logger *Logger) {
+				logger.Named("iam").Info("my info message")
+			},
+			want: `{"level":"info","logger":"iam","context":{},"timestamp":"2022-03-05T00:00:00Z","message":"my info message"}`,
+		},
+		"joins nested logger names": {
+			log: func(logger *Logger) {
+				logger.Named("iam").Named("identity").With(Str("requestId", "abc-123")).Info("my info message")
+			},
+			want: `{"level":"info","logger":"iam.identity","context":{"requestId":"abc-123"},"timestamp":"2022-03-05T00:00:00Z","message":"my info message"}`,
+		},
 	}
 
 	for testName, testCase := range testCases {
@@ -144,7 +234,65 @@ func TestToZerologLevel(t *testing.T) {
 		t.Run(tn, func(t *testing.T) {
 			t.Parallel()
 
-			assert.Equal(t, tc.want, toZerologLevel(tc.level))
+			var buffer bytes.Buffer
+
+			tc.log(FromAdapter(zerologAdapter{logger: zerolog.New(&buffer)}, WithTimestampFactory(func() time.Time {
+				return time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC)
+			})))
+			assert.Equal(t, tc.want+"\n", buffer.String())
 		})
 	}
 }
+
+func TestZerologAdapterWithEncoderConfig(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	config := EncoderConfig{
+		TimestampKey: "ts",
+		LevelKey:     "severity",
+		MessageKey:   "msg",
+		ContextKey:   "fields",
+		TimeFormat:   time.RFC1123,
+		DurationUnit: time.Second,
+		Host:         "",
+	}
+
+	newZerologAdapter(&buffer, nil, config).AdaptEntry(Entry{
+		Time:    time.Date(2022, time.March, 5, 0, 0, 0, 0, time.UTC),
+		Level:   WarnLevel,
+		Message: "my warn message",
+		Fields: []Field{
+			Duration("durationFieldKey", 1500*time.Millisecond),
+			Time("timeFieldKey", time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)),
+			Array("arrayFieldKey", testArray{Duration("", time.Minute)}),
+		},
+	})
+
+	assert.Equal(
+		t,
+		`{"severity":"warn","fields":{"durationFieldKey":1.5,"timeFieldKey":"Mon, 01 Feb 2021 00:00:00 UTC",`+
+			`"arrayFieldKey":[60]},"ts":"Sat, 05 Mar 2022 00:00:00 UTC","msg":"my warn message"}`+"\n",
+		buffer.String(),
+	)
+}
+
+func TestZerologAdapterWritesMetadata(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+
+	entry := newEntry(ErrorLevel, "my error message", nil)
+	entry.Caller = Caller{Defined: true, PC: 1, Function: "main.main", File: "/app/main.go", Line: 42}
+	entry.Stack = []string{"main.main /app/main.go:42", "runtime.main /usr/local/go/src/runtime/proc.go:250"}
+
+	zerologAdapter{logger: zerolog.New(&buffer)}.AdaptEntry(entry)
+
+	assert.Equal(
+		t,
+		`{"level":"error","caller":"/app/main.go:42","stack":["main.main /app/main.go:42",`+
+			`"runtime.main /usr/local/go/src/runtime/proc.go:250"],"context":{},"message":"my error message"}`+"\n",
+		buffer.String(),
+	)
+}
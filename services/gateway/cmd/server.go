@@ -6,6 +6,7 @@ import (
 
 	"github.com/nickbryan/collectable/services/gateway/internal/rest/health"
 
+	"github.com/nickbryan/collectable/libraries/lgr"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -25,10 +26,17 @@ var serverCmd = &cobra.Command{
 	Short: "Start the JSON REST API server.",
 	Long:  "Start the web server that will convert JSON requests to gRPC requests and proxy to the appropriate internal service.",
 	RunE: func(_ *cobra.Command, _ []string) (err error) {
-		logger, err := zap.NewProduction()
+		log, err := lgr.NewFromEnv("GATEWAY")
 		if err != nil {
 			return fmt.Errorf("initialising logger: %w", err)
 		}
+		defer func(log *lgr.Logger) {
+			if closeErr := log.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("closing logger: %w", closeErr)
+			}
+		}(log)
+
+		logger := zap.New(lgr.NewZapCore(log))
 
 		target := fmt.Sprintf("%s:%s", os.Getenv("IAM_SERVICE_HOST"), os.Getenv("IAM_SERVICE_PORT"))
 		conn, err := grpc.Dial(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
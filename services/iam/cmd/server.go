@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/log/zapadapter"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/nickbryan/collectable/libraries/lgr"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -31,11 +32,18 @@ var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Start the gRPC server.",
 	Long:  "Start the gRPC server that will handle iam requests from the gateway service.",
-	RunE: func(_ *cobra.Command, _ []string) error {
-		logger, err := zap.NewProduction()
+	RunE: func(_ *cobra.Command, _ []string) (err error) {
+		log, err := lgr.NewFromEnv("IAM")
 		if err != nil {
 			return fmt.Errorf("initialising logger: %w", err)
 		}
+		defer func(log *lgr.Logger) {
+			if closeErr := log.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("closing logger: %w", closeErr)
+			}
+		}(log)
+
+		logger := zap.New(lgr.NewZapCore(log))
 
 		lis, err := net.Listen("tcp", "0.0.0.0:8081")
 		if err != nil {